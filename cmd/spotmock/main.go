@@ -0,0 +1,33 @@
+package main
+
+import (
+	"context"
+	"crypto_pro/internal/app/spotmock"
+	"crypto_pro/internal/configs"
+	"crypto_pro/pkg/logger"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+func main() {
+	fmt.Println("Try get configs file path from env")
+
+	configsPath, exists := os.LookupEnv("CONFIGS_APP")
+	if !exists {
+		panic("Сonfigs app file path is out")
+	}
+
+	cfg := configs.New(configsPath)
+
+	logger := logger.New(cfg.GetBool("log_to_file"))
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	app := spotmock.NewApp(ctx, logger, *cfg)
+	if err := app.Run(); err != nil {
+		logger.Fatal("spot mock stopped", logger.ErrorC(err))
+	}
+}
@@ -2,11 +2,12 @@ package adapters
 
 import (
 	"crypto_pro/internal/domain/entity"
+	"time"
 )
 
 type DbAdapter interface {
 	Close()
-	UpsertDWHTransactions(transactions []entity.Transaction) error
+	UpsertDWHTransactions(id string, transactions []entity.Transaction) error
 	SelectTransactions(id string) []entity.Transaction
 	DeleteSession(id string)
 	TrancateRawTransactions()
@@ -14,4 +15,40 @@ type DbAdapter interface {
 	SelectTransactionsBySymbol(id string, symbol, marketFrom, marketTo string) entity.Transaction
 	SelectNewTransactions(id string) []entity.Transaction
 	CreateSession(id string, usdt, spreadMin, spreadMax float64)
+	SelectSession(id string) entity.Session
+	SelectSessions(prefix string) []entity.Session
+	UpdateSessionMarkets(id string, buyMarkets, sellMarkets []string) error
+	UpdateSessionChains(id string, includeChains, excludeChains []string) error
+	UpdateSessionGuards(id string, guards entity.Guards) error
+	UpdateSessionAlerts(id string, alerts entity.Alerts) error
+	SelectPostedTransactions(id string) []entity.SpreadChange
+	UpdatePostedSnapshot(transaction entity.Transaction) error
+	UpdateSessionNotifyClosed(id string, notifyClosed bool) error
+	UpdateSessionQuietPending(id string, quietPending bool) (bool, error)
+	SelectClosedTransactions(id string) []entity.Transaction
+	StreamTransactions(id string, filter entity.ExportFilter,
+		handler func(transaction entity.Transaction) error) error
+	SelectSpreadHistory(id, symbol, chain, marketFrom, marketTo string, since time.Time) []entity.SpreadPoint
+	SelectUser(id string) (entity.User, error)
+	UpsertUserSettings(user entity.User) error
+	AddUserSymbols(id, list string, symbols []string) error
+	RemoveUserSymbols(id, list string, symbols []string) error
+	InsertFavorite(favorite entity.Favorite) error
+	SelectFavorites() []entity.Favorite
+	SelectUserFavorites(userID string) []entity.Favorite
+	UpdateFavoriteThreshold(userID string, id int64, threshold float64) bool
+	UpdateFavoriteState(id int64, spread float64, isAbove bool) error
+	DeleteFavorite(userID string, id int64) bool
+	UpsertDigest(digest entity.Digest) error
+	SelectDigest(userID string) entity.Digest
+	SelectDigests() []entity.Digest
+	UpdateDigestSentAt(userID string, sentAt time.Time) error
+	DeleteDigest(userID string) error
+	SelectDigestTransactions(ownerID string, since time.Time) []entity.Transaction
+	SelectChatThread(id string) int
+	UpsertChatThread(id string, threadID int) error
+	InsertSchedule(schedule entity.Schedule) error
+	SelectSchedules() []entity.Schedule
+	SelectOwnerSchedules(prefix string) []entity.Schedule
+	DeleteSchedule(prefix string, id int64) (entity.Schedule, bool)
 }
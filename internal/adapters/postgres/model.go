@@ -2,7 +2,9 @@ package postgres
 
 import (
 	"crypto_pro/internal/domain/entity"
+	"database/sql"
 	"encoding/json"
+	"fmt"
 	"time"
 )
 
@@ -58,6 +60,242 @@ func (t transactions) toEntity() []entity.Transaction {
 			AmountBidOrder: val.AmountBidOrder,
 			BidCost:        val.BidCost,
 			BidOrder:       bidOrder,
+			UpdatedAt:      val.UpdatedAt,
+		})
+	}
+	return response
+}
+
+type postedTransactions []postedTransaction
+
+type postedTransaction struct {
+	ID                   string          `db:"id"`
+	Symbol               string          `db:"symbol"`
+	Chain                string          `db:"chain"`
+	MarketFrom           string          `db:"market_from"`
+	MarketTo             string          `db:"market_to"`
+	Spread               float64         `db:"spread"`
+	WithDrawFee          float64         `db:"with_draw_fee"`
+	WithdrawMax          float64         `db:"withdraw_max"`
+	AmountCoin           float64         `db:"amount_coin"`
+	AmountAskOrder       float64         `db:"amount_ask_order"`
+	AskCost              float64         `db:"ask_cost"`
+	AskOrder             json.RawMessage `db:"ask_order"`
+	AmountBidOrder       float64         `db:"amount_bid_order"`
+	BidCost              float64         `db:"bid_cost"`
+	BidOrder             json.RawMessage `db:"bid_order"`
+	UpdatedAt            time.Time       `db:"updated_at"`
+	PostedSpread         float64         `db:"posted_spread"`
+	PostedAmountAskOrder float64         `db:"posted_amount_ask_order"`
+	PostedAskCost        float64         `db:"posted_ask_cost"`
+	PostedAskOrder       json.RawMessage `db:"posted_ask_order"`
+	PostedAmountBidOrder float64         `db:"posted_amount_bid_order"`
+	PostedBidCost        float64         `db:"posted_bid_cost"`
+	PostedBidOrder       json.RawMessage `db:"posted_bid_order"`
+}
+
+func (t postedTransactions) toEntity() []entity.SpreadChange {
+	response := []entity.SpreadChange{}
+	for _, val := range t {
+		current := transactions{{
+			ID:             val.ID,
+			Symbol:         val.Symbol,
+			Chain:          val.Chain,
+			MarketFrom:     val.MarketFrom,
+			MarketTo:       val.MarketTo,
+			Spread:         val.Spread,
+			WithDrawFee:    val.WithDrawFee,
+			WithdrawMax:    val.WithdrawMax,
+			AmountCoin:     val.AmountCoin,
+			AmountAskOrder: val.AmountAskOrder,
+			AskCost:        val.AskCost,
+			AskOrder:       val.AskOrder,
+			AmountBidOrder: val.AmountBidOrder,
+			BidCost:        val.BidCost,
+			BidOrder:       val.BidOrder,
+			UpdatedAt:      val.UpdatedAt,
+		}}.toEntity()
+		if len(current) == 0 {
+			return nil
+		}
+
+		previous := current[0]
+		previous.Spread = val.PostedSpread
+		previous.AmountAskOrder = val.PostedAmountAskOrder
+		previous.AskCost = val.PostedAskCost
+		previous.AmountBidOrder = val.PostedAmountBidOrder
+		previous.BidCost = val.PostedBidCost
+
+		previous.AskOrder = []entity.Order{}
+		if len(val.PostedAskOrder) != 0 && json.Unmarshal(val.PostedAskOrder, &previous.AskOrder) != nil {
+			return nil
+		}
+
+		previous.BidOrder = []entity.Order{}
+		if len(val.PostedBidOrder) != 0 && json.Unmarshal(val.PostedBidOrder, &previous.BidOrder) != nil {
+			return nil
+		}
+
+		response = append(response, entity.SpreadChange{
+			Previous: previous,
+			Current:  current[0],
+		})
+	}
+	return response
+}
+
+type closedTransactions []closedTransaction
+
+type closedTransaction struct {
+	ID         string    `db:"id"`
+	Symbol     string    `db:"symbol"`
+	Chain      string    `db:"chain"`
+	MarketFrom string    `db:"market_from"`
+	MarketTo   string    `db:"market_to"`
+	Spread     float64   `db:"spread"`
+	PeakSpread float64   `db:"peak_spread"`
+	CreatedAt  time.Time `db:"created_at"`
+	ClosedAt   time.Time `db:"closed_at"`
+}
+
+func (t closedTransactions) toEntity() []entity.Transaction {
+	response := []entity.Transaction{}
+	for _, val := range t {
+		response = append(response, entity.Transaction{
+			ID:         val.ID,
+			Symbol:     val.Symbol,
+			Chain:      val.Chain,
+			MarketFrom: val.MarketFrom,
+			MarketTo:   val.MarketTo,
+			Spread:     val.Spread,
+			PeakSpread: val.PeakSpread,
+			IsPosted:   true,
+			CreatedAt:  val.CreatedAt,
+			ClosedAt:   val.ClosedAt,
+		})
+	}
+	return response
+}
+
+type exportedTransaction struct {
+	Symbol         string       `db:"symbol"`
+	Chain          string       `db:"chain"`
+	MarketFrom     string       `db:"market_from"`
+	MarketTo       string       `db:"market_to"`
+	Spread         float64      `db:"spread"`
+	PeakSpread     float64      `db:"peak_spread"`
+	WithDrawFee    float64      `db:"with_draw_fee"`
+	AmountCoin     float64      `db:"amount_coin"`
+	AmountAskOrder float64      `db:"amount_ask_order"`
+	AskCost        float64      `db:"ask_cost"`
+	AmountBidOrder float64      `db:"amount_bid_order"`
+	BidCost        float64      `db:"bid_cost"`
+	CreatedAt      sql.NullTime `db:"created_at"`
+	UpdatedAt      sql.NullTime `db:"updated_at"`
+	ClosedAt       sql.NullTime `db:"closed_at"`
+}
+
+func (t exportedTransaction) toEntity() entity.Transaction {
+	return entity.Transaction{
+		Symbol:         t.Symbol,
+		Chain:          t.Chain,
+		MarketFrom:     t.MarketFrom,
+		MarketTo:       t.MarketTo,
+		Spread:         t.Spread,
+		PeakSpread:     t.PeakSpread,
+		WithDrawFee:    t.WithDrawFee,
+		AmountCoin:     t.AmountCoin,
+		AmountAskOrder: t.AmountAskOrder,
+		AskCost:        t.AskCost,
+		AmountBidOrder: t.AmountBidOrder,
+		BidCost:        t.BidCost,
+		CreatedAt:      t.CreatedAt.Time,
+		UpdatedAt:      t.UpdatedAt.Time,
+		ClosedAt:       t.ClosedAt.Time,
+	}
+}
+
+type favorites []favorite
+
+type favorite struct {
+	ID         int64        `db:"id"`
+	UserID     string       `db:"user_id"`
+	ChatID     string       `db:"chat_id"`
+	Symbol     string       `db:"symbol"`
+	Chain      string       `db:"chain"`
+	MarketFrom string       `db:"market_from"`
+	MarketTo   string       `db:"market_to"`
+	USDT       float64      `db:"usdt"`
+	Threshold  float64      `db:"threshold"`
+	Spread     float64      `db:"spread"`
+	IsAbove    bool         `db:"is_above"`
+	CheckedAt  sql.NullTime `db:"checked_at"`
+}
+
+func (f favorites) toEntity() []entity.Favorite {
+	response := []entity.Favorite{}
+	for _, val := range f {
+		response = append(response, entity.Favorite{
+			ID:         val.ID,
+			UserID:     val.UserID,
+			ChatID:     val.ChatID,
+			Symbol:     val.Symbol,
+			Chain:      val.Chain,
+			MarketFrom: val.MarketFrom,
+			MarketTo:   val.MarketTo,
+			USDT:       val.USDT,
+			Threshold:  val.Threshold,
+			Spread:     val.Spread,
+			IsAbove:    val.IsAbove,
+			CheckedAt:  val.CheckedAt.Time,
+		})
+	}
+	return response
+}
+
+type digests []digest
+
+type digest struct {
+	UserID  string    `db:"user_id"`
+	ChatID  string    `db:"chat_id"`
+	Period  string    `db:"period"`
+	At      string    `db:"at"`
+	Weekday int       `db:"weekday"`
+	SentAt  time.Time `db:"sent_at"`
+}
+
+func (d digests) toEntity() []entity.Digest {
+	response := []entity.Digest{}
+	for _, val := range d {
+		response = append(response, entity.Digest{
+			UserID:  val.UserID,
+			ChatID:  val.ChatID,
+			Period:  val.Period,
+			At:      val.At,
+			Weekday: time.Weekday(val.Weekday),
+			SentAt:  val.SentAt,
+		})
+	}
+	return response
+}
+
+type spreadPoints []spreadPoint
+
+type spreadPoint struct {
+	Spread    float64   `db:"spread"`
+	AskCost   float64   `db:"ask_cost"`
+	BidCost   float64   `db:"bid_cost"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+func (p spreadPoints) toEntity() []entity.SpreadPoint {
+	response := []entity.SpreadPoint{}
+	for _, val := range p {
+		response = append(response, entity.SpreadPoint{
+			Spread:    val.Spread,
+			AskCost:   val.AskCost,
+			BidCost:   val.BidCost,
+			CreatedAt: val.CreatedAt,
 		})
 	}
 	return response
@@ -98,3 +336,194 @@ func fromEntityToModel(transactionsEntity []entity.Transaction) (transactions, e
 
 	return transactions, nil
 }
+
+type sessions []session
+
+type session struct {
+	ID            string          `db:"id"`
+	USDT          float64         `db:"usdt"`
+	SpreadMin     float64         `db:"spread_min"`
+	SpreadMax     float64         `db:"spread_max"`
+	BuyMarkets    json.RawMessage `db:"buy_markets"`
+	SellMarkets   json.RawMessage `db:"sell_markets"`
+	IncludeChains json.RawMessage `db:"include_chains"`
+	ExcludeChains json.RawMessage `db:"exclude_chains"`
+	Guards        json.RawMessage `db:"guards"`
+	Alerts        json.RawMessage `db:"alerts"`
+	NotifyClosed  bool            `db:"notify_closed"`
+	QuietPending  bool            `db:"quiet_pending"`
+}
+
+type alerts struct {
+	SpreadAbs float64 `json:"spread_abs"`
+	SpreadRel float64 `json:"spread_rel"`
+}
+
+func (a alerts) toEntity() entity.Alerts {
+	return entity.Alerts{
+		SpreadAbs: a.SpreadAbs,
+		SpreadRel: a.SpreadRel,
+	}
+}
+
+func fromAlertsEntityToModel(alertsEntity entity.Alerts) alerts {
+	return alerts{
+		SpreadAbs: alertsEntity.SpreadAbs,
+		SpreadRel: alertsEntity.SpreadRel,
+	}
+}
+
+type guards struct {
+	MaxFeePercent    float64 `json:"max_fee_percent"`
+	CheckWithdrawMax bool    `json:"check_withdraw_max"`
+	MinOrders        int     `json:"min_orders"`
+	MinDepthUSDT     float64 `json:"min_depth_usdt"`
+}
+
+func (g guards) toEntity() entity.Guards {
+	return entity.Guards{
+		MaxFeePercent:    g.MaxFeePercent,
+		CheckWithdrawMax: g.CheckWithdrawMax,
+		MinOrders:        g.MinOrders,
+		MinDepthUSDT:     g.MinDepthUSDT,
+	}
+}
+
+func fromGuardsEntityToModel(guardsEntity entity.Guards) guards {
+	return guards{
+		MaxFeePercent:    guardsEntity.MaxFeePercent,
+		CheckWithdrawMax: guardsEntity.CheckWithdrawMax,
+		MinOrders:        guardsEntity.MinOrders,
+		MinDepthUSDT:     guardsEntity.MinDepthUSDT,
+	}
+}
+
+func (s sessions) toEntity() []entity.Session {
+	response := []entity.Session{}
+	for _, val := range s {
+		response = append(response, val.toEntity())
+	}
+	return response
+}
+
+func (s session) toEntity() entity.Session {
+	buyMarkets := []string{}
+	if len(s.BuyMarkets) != 0 && json.Unmarshal(s.BuyMarkets, &buyMarkets) != nil {
+		return entity.Session{}
+	}
+
+	sellMarkets := []string{}
+	if len(s.SellMarkets) != 0 && json.Unmarshal(s.SellMarkets, &sellMarkets) != nil {
+		return entity.Session{}
+	}
+
+	includeChains := []string{}
+	if len(s.IncludeChains) != 0 && json.Unmarshal(s.IncludeChains, &includeChains) != nil {
+		return entity.Session{}
+	}
+
+	excludeChains := []string{}
+	if len(s.ExcludeChains) != 0 && json.Unmarshal(s.ExcludeChains, &excludeChains) != nil {
+		return entity.Session{}
+	}
+
+	guards := guards{}
+	if len(s.Guards) != 0 && json.Unmarshal(s.Guards, &guards) != nil {
+		return entity.Session{}
+	}
+
+	alerts := alerts{}
+	if len(s.Alerts) != 0 && json.Unmarshal(s.Alerts, &alerts) != nil {
+		return entity.Session{}
+	}
+
+	return entity.Session{
+		ID:            s.ID,
+		USDT:          s.USDT,
+		SpreadMin:     s.SpreadMin,
+		SpreadMax:     s.SpreadMax,
+		BuyMarkets:    buyMarkets,
+		SellMarkets:   sellMarkets,
+		IncludeChains: includeChains,
+		ExcludeChains: excludeChains,
+		Guards:        guards.toEntity(),
+		Alerts:        alerts.toEntity(),
+		NotifyClosed:  s.NotifyClosed,
+		QuietPending:  s.QuietPending,
+	}
+}
+
+type user struct {
+	ID            string          `db:"id"`
+	Blacklist     json.RawMessage `db:"blacklist"`
+	Watchlist     json.RawMessage `db:"watchlist"`
+	WatchlistOnly bool            `db:"watchlist_only"`
+	TimeZone      string          `db:"time_zone"`
+	QuietFrom     string          `db:"quiet_from"`
+	QuietTo       string          `db:"quiet_to"`
+}
+
+func (u user) toEntity(id string) (entity.User, error) {
+	blacklist := []string{}
+	if len(u.Blacklist) != 0 {
+		if err := json.Unmarshal(u.Blacklist, &blacklist); err != nil {
+			return entity.User{}, err
+		}
+	}
+
+	watchlist := []string{}
+	if len(u.Watchlist) != 0 {
+		if err := json.Unmarshal(u.Watchlist, &watchlist); err != nil {
+			return entity.User{}, err
+		}
+	}
+
+	return entity.User{
+		ID:            id,
+		Blacklist:     blacklist,
+		Watchlist:     watchlist,
+		WatchlistOnly: u.WatchlistOnly,
+		TimeZone:      u.TimeZone,
+		QuietFrom:     u.QuietFrom,
+		QuietTo:       u.QuietTo,
+	}, nil
+}
+
+func symbolListColumn(list string) (string, error) {
+	switch list {
+	case entity.UserBlacklist, entity.UserWatchlist:
+		return list, nil
+	}
+	return "", fmt.Errorf("unknown symbol list %q", list)
+}
+
+type schedules []schedule
+
+type schedule struct {
+	ID        int64           `db:"id"`
+	SessionID string          `db:"session_id"`
+	Request   string          `db:"request"`
+	Weekdays  json.RawMessage `db:"weekdays"`
+	StartAt   string          `db:"start_at"`
+	StopAt    string          `db:"stop_at"`
+}
+
+func (s schedules) toEntity() []entity.Schedule {
+	response := []entity.Schedule{}
+	for _, val := range s {
+		weekdays := []time.Weekday{}
+		if json.Unmarshal(val.Weekdays, &weekdays) != nil {
+			return nil
+		}
+
+		response = append(response, entity.Schedule{
+			ID:        val.ID,
+			SessionID: val.SessionID,
+			Request:   val.Request,
+			Weekdays:  weekdays,
+			Start:     val.StartAt,
+			Stop:      val.StopAt,
+		})
+	}
+	return response
+}
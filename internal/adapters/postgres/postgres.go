@@ -5,9 +5,12 @@ import (
 	"crypto_pro/internal/adapters"
 	"crypto_pro/internal/domain/entity"
 	"crypto_pro/pkg/logger"
+	"database/sql"
+	"encoding/json"
 	"fmt"
 	"net"
 	"os"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -44,6 +47,10 @@ func New(ctx context.Context, cfg viper.Viper, log logger.Logger) *PostresReposi
 		}
 	}
 
+	if err := db.migrate(); err != nil {
+		log.Panic("failed to migrate db schema", log.ErrorC(err))
+	}
+
 	return &db
 }
 
@@ -110,7 +117,7 @@ func (d *PostresRepository) Close() {
 	client.Close()
 }
 
-func (d *PostresRepository) UpsertDWHTransactions(transactionsEntity []entity.Transaction) error {
+func (d *PostresRepository) UpsertDWHTransactions(id string, transactionsEntity []entity.Transaction) error {
 	tx := d.client.Begin()
 	if tx.Error != nil {
 		return tx.Error
@@ -141,33 +148,46 @@ func (d *PostresRepository) UpsertDWHTransactions(transactionsEntity []entity.Tr
 			timeNow)
 	}
 
-	insertQuery := fmt.Sprintf(`
-		INSERT INTO raw_transactions (id, symbol, chain, market_from, market_to, spread, 
-			with_draw_fee, withdraw_max, amount_coin, amount_ask_order, ask_cost, ask_order,
-			amount_bid_order, bid_cost, bid_order, updated_at)
-		VALUES %s
-	`, strings.Join(values, ","))
+	if len(values) != 0 {
+		insertQuery := fmt.Sprintf(`
+			INSERT INTO raw_transactions (id, symbol, chain, market_from, market_to, spread, 
+				with_draw_fee, withdraw_max, amount_coin, amount_ask_order, ask_cost, ask_order,
+				amount_bid_order, bid_cost, bid_order, updated_at)
+			VALUES %s
+		`, strings.Join(values, ","))
 
-	if err := tx.Exec(insertQuery, insertArgs...).Error; err != nil {
-		return err
+		if err := tx.Exec(insertQuery, insertArgs...).Error; err != nil {
+			return err
+		}
 	}
 
 	deleteQuery := `
-		DELETE FROM dwh_transactions
-		WHERE (id, symbol, chain, market_from, market_to) NOT IN (
-			SELECT id, symbol, chain, market_from, market_to
-			FROM raw_transactions
+		WITH closed AS (
+			DELETE FROM dwh_transactions
+			WHERE id = $1 AND (id, symbol, chain, market_from, market_to) NOT IN (
+				SELECT id, symbol, chain, market_from, market_to
+				FROM raw_transactions
+				WHERE id = $1
+			)
+			RETURNING *
 		)
+		INSERT INTO dwh_closed_transactions (id, symbol, chain, market_from, market_to, spread,
+			peak_spread, is_posted, created_at, closed_at, with_draw_fee, amount_coin, amount_ask_order,
+			ask_cost, amount_bid_order, bid_cost, updated_at)
+		SELECT id, symbol, chain, market_from, market_to, spread, peak_spread, is_posted, created_at,
+			CURRENT_TIMESTAMP, with_draw_fee, amount_coin, amount_ask_order, ask_cost, amount_bid_order,
+			bid_cost, updated_at
+		FROM closed
 	`
 
-	if err := tx.Exec(deleteQuery).Error; err != nil {
+	if err := tx.Exec(deleteQuery, id).Error; err != nil {
 		return err
 	}
 
-	insertQuery = `
+	insertQuery := `
 		INSERT INTO dwh_transactions (id, symbol, chain, market_from, market_to, spread, 
 			with_draw_fee, withdraw_max, amount_coin, amount_ask_order, ask_cost, ask_order,
-			amount_bid_order, bid_cost, bid_order, updated_at)
+			amount_bid_order, bid_cost, bid_order, updated_at, created_at, peak_spread)
 		SELECT
 			r.id,
 			r.symbol,
@@ -184,8 +204,11 @@ func (d *PostresRepository) UpsertDWHTransactions(transactionsEntity []entity.Tr
 			r.amount_bid_order,
 			r.bid_cost,
 			r.bid_order,
-			r.updated_at
+			r.updated_at,
+			r.updated_at,
+			r.spread
 		FROM raw_transactions r
+		WHERE r.id = $1
 		ON CONFLICT (id, symbol, chain, market_from, market_to) DO UPDATE
 		SET
 			spread = EXCLUDED.spread,
@@ -198,22 +221,54 @@ func (d *PostresRepository) UpsertDWHTransactions(transactionsEntity []entity.Tr
 			amount_bid_order = EXCLUDED.amount_bid_order,
 			bid_cost = EXCLUDED.bid_cost,
 			bid_order = EXCLUDED.bid_order,
-			updated_at = CURRENT_TIMESTAMP
+			updated_at = CURRENT_TIMESTAMP,
+			peak_spread = GREATEST(dwh_transactions.peak_spread, EXCLUDED.spread)
 	`
 
-	if err := tx.Exec(insertQuery).Error; err != nil {
+	if err := tx.Exec(insertQuery, id).Error; err != nil {
 		return err
 	}
 
-	deleteQuery = fmt.Sprintf(`
-		DELETE FROM raw_transactions
-		WHERE id = '%s'
-	`, transactionsModel[0].ID)
+	historyQuery := `
+		INSERT INTO dwh_spread_history (id, symbol, chain, market_from, market_to, spread, ask_cost, bid_cost,
+			created_at)
+		SELECT id, symbol, chain, market_from, market_to, spread, ask_cost, bid_cost, updated_at
+		FROM raw_transactions
+		WHERE id = $1
+	`
+
+	if err := tx.Exec(historyQuery, id).Error; err != nil {
+		return err
+	}
+
+	deleteQuery = `
+		DELETE FROM dwh_spread_history
+		WHERE id = $1 AND created_at < CURRENT_TIMESTAMP - INTERVAL '24 hours'
+	`
+
+	if err := tx.Exec(deleteQuery, id).Error; err != nil {
+		return err
+	}
+
+	// Weekly digests read closed deals of the past week, keep one more day for late reports.
+	deleteQuery = `
+		DELETE FROM dwh_closed_transactions
+		WHERE closed_at < CURRENT_TIMESTAMP - INTERVAL '8 days'
+	`
 
 	if err := tx.Exec(deleteQuery).Error; err != nil {
 		return err
 	}
 
+	deleteQuery = `
+		DELETE FROM raw_transactions
+		WHERE id = $1
+	`
+
+	if err := tx.Exec(deleteQuery, id).Error; err != nil {
+		return err
+	}
+
 	return tx.Commit().Error
 }
 
@@ -345,18 +400,589 @@ func (d *PostresRepository) SelectNewTransactions(id string) []entity.Transactio
 }
 
 func (d *PostresRepository) updateTransactionIsPosted(id string) error {
-	if err := d.client.Exec("UPDATE dwh_transactions SET is_posted = true WHERE id = ?", id).Error; err != nil {
+	if err := d.client.Exec(`
+		UPDATE dwh_transactions
+		SET
+			is_posted = true,
+			posted_spread = spread,
+			posted_amount_ask_order = amount_ask_order,
+			posted_ask_cost = ask_cost,
+			posted_ask_order = ask_order,
+			posted_amount_bid_order = amount_bid_order,
+			posted_bid_cost = bid_cost,
+			posted_bid_order = bid_order
+		WHERE id = ? AND is_posted = false`, id).Error; err != nil {
 		d.log.Error("error update transactions", d.log.ErrorC(err))
 		return err
 	}
 	return nil
 }
 
+func (d *PostresRepository) SelectPostedTransactions(id string) []entity.SpreadChange {
+	var postedTransactions postedTransactions
+
+	if err := d.client.Raw(`
+		SELECT
+			id,
+			symbol,
+			chain,
+			market_from,
+			market_to,
+			spread,
+			with_draw_fee,
+			withdraw_max,
+			amount_coin,
+			amount_ask_order,
+			ask_cost,
+			ask_order,
+			amount_bid_order,
+			bid_cost,
+			bid_order,
+			updated_at,
+			posted_spread,
+			posted_amount_ask_order,
+			posted_ask_cost,
+			posted_ask_order,
+			posted_amount_bid_order,
+			posted_bid_cost,
+			posted_bid_order
+		FROM dwh_transactions
+		WHERE id = $1 AND is_posted = true`, id).Scan(&postedTransactions).Error; err != nil {
+		d.log.Error("error select posted transactions", d.log.ErrorC(err))
+		return nil
+	}
+
+	return postedTransactions.toEntity()
+}
+
+func (d *PostresRepository) UpdatePostedSnapshot(transaction entity.Transaction) error {
+	if err := d.client.Exec(`
+		UPDATE dwh_transactions
+		SET
+			posted_spread = spread,
+			posted_amount_ask_order = amount_ask_order,
+			posted_ask_cost = ask_cost,
+			posted_ask_order = ask_order,
+			posted_amount_bid_order = amount_bid_order,
+			posted_bid_cost = bid_cost,
+			posted_bid_order = bid_order
+		WHERE id = ? AND symbol = ? AND chain = ? AND market_from = ? AND market_to = ?`,
+		transaction.ID, transaction.Symbol, transaction.Chain, transaction.MarketFrom,
+		transaction.MarketTo).Error; err != nil {
+		d.log.Error("error update posted snapshot", d.log.ErrorC(err))
+		return err
+	}
+	return nil
+}
+
+func (d *PostresRepository) UpdateSessionAlerts(id string, alerts entity.Alerts) error {
+	alertsJSON, err := json.Marshal(fromAlertsEntityToModel(alerts))
+	if err != nil {
+		return err
+	}
+
+	if err := d.client.Exec("UPDATE dwh_sessions SET alerts = ? WHERE id = ?", string(alertsJSON),
+		id).Error; err != nil {
+		d.log.Error("error update session alerts", d.log.ErrorC(err))
+		return err
+	}
+	return nil
+}
+
 func (d *PostresRepository) CreateSession(id string, usdt, spreadMin, spreadMax float64) {
-	if err := d.client.Exec("INSERT INTO dwh_sessions (id, usdt, spread_min, spread_max) VALUES (?, ?, ?, ?)", id,
+	if err := d.client.Exec(`
+		INSERT INTO dwh_sessions (id, usdt, spread_min, spread_max) VALUES (?, ?, ?, ?)
+		ON CONFLICT (id) DO UPDATE
+		SET
+			usdt = EXCLUDED.usdt,
+			spread_min = EXCLUDED.spread_min,
+			spread_max = EXCLUDED.spread_max`, id,
 		strconv.FormatFloat(usdt, 'f', -1, 64), strconv.FormatFloat(spreadMin, 'f', -1, 64),
 		strconv.FormatFloat(spreadMax, 'f', -1, 64)).Error; err != nil {
 
 		d.log.Error("error create session", d.log.ErrorC(err))
 	}
 }
+
+func (d *PostresRepository) SelectSession(id string) entity.Session {
+	var session session
+
+	if err := d.client.Raw(`
+		SELECT id, usdt, spread_min, spread_max, buy_markets, sell_markets, include_chains,
+			exclude_chains, guards, alerts, notify_closed, quiet_pending
+		FROM dwh_sessions WHERE id = $1`, id).Scan(&session).Error; err != nil {
+		d.log.Error("error select session", d.log.ErrorC(err))
+		return entity.Session{}
+	}
+	return session.toEntity()
+}
+
+func (d *PostresRepository) SelectSessions(prefix string) []entity.Session {
+	var sessions sessions
+
+	if err := d.client.Raw(`
+		SELECT id, usdt, spread_min, spread_max, buy_markets, sell_markets, include_chains,
+			exclude_chains, guards, alerts, notify_closed, quiet_pending
+		FROM dwh_sessions WHERE starts_with(id, $1) ORDER BY id`, prefix).Scan(&sessions).Error; err != nil {
+		d.log.Error("error select sessions", d.log.ErrorC(err))
+		return nil
+	}
+	return sessions.toEntity()
+}
+
+func (d *PostresRepository) UpdateSessionMarkets(id string, buyMarkets, sellMarkets []string) error {
+	buyMarketsJSON, err := json.Marshal(buyMarkets)
+	if err != nil {
+		return err
+	}
+	sellMarketsJSON, err := json.Marshal(sellMarkets)
+	if err != nil {
+		return err
+	}
+
+	if err := d.client.Exec("UPDATE dwh_sessions SET buy_markets = ?, sell_markets = ? WHERE id = ?",
+		string(buyMarketsJSON), string(sellMarketsJSON), id).Error; err != nil {
+		d.log.Error("error update session markets", d.log.ErrorC(err))
+		return err
+	}
+	return nil
+}
+
+func (d *PostresRepository) UpdateSessionChains(id string, includeChains, excludeChains []string) error {
+	includeChainsJSON, err := json.Marshal(includeChains)
+	if err != nil {
+		return err
+	}
+	excludeChainsJSON, err := json.Marshal(excludeChains)
+	if err != nil {
+		return err
+	}
+
+	if err := d.client.Exec("UPDATE dwh_sessions SET include_chains = ?, exclude_chains = ? WHERE id = ?",
+		string(includeChainsJSON), string(excludeChainsJSON), id).Error; err != nil {
+		d.log.Error("error update session chains", d.log.ErrorC(err))
+		return err
+	}
+	return nil
+}
+
+func (d *PostresRepository) UpdateSessionGuards(id string, guards entity.Guards) error {
+	guardsJSON, err := json.Marshal(fromGuardsEntityToModel(guards))
+	if err != nil {
+		return err
+	}
+
+	if err := d.client.Exec("UPDATE dwh_sessions SET guards = ? WHERE id = ?", string(guardsJSON),
+		id).Error; err != nil {
+		d.log.Error("error update session guards", d.log.ErrorC(err))
+		return err
+	}
+	return nil
+}
+
+func (d *PostresRepository) SelectUser(id string) (entity.User, error) {
+	var user user
+
+	if err := d.client.Raw(`
+		SELECT id, blacklist, watchlist, watchlist_only, time_zone, quiet_from, quiet_to
+		FROM dwh_users WHERE id = $1`, id).Scan(&user).Error; err != nil {
+		d.log.Error("error select user", d.log.ErrorC(err))
+		return entity.User{}, err
+	}
+
+	userEntity, err := user.toEntity(id)
+	if err != nil {
+		d.log.Error("error decode user", d.log.ErrorC(err))
+		return entity.User{}, err
+	}
+	return userEntity, nil
+}
+
+func (d *PostresRepository) UpsertUserSettings(userEntity entity.User) error {
+	if err := d.client.Exec(`
+		INSERT INTO dwh_users (id, watchlist_only, time_zone, quiet_from, quiet_to) VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT (id) DO UPDATE
+		SET
+			watchlist_only = EXCLUDED.watchlist_only,
+			time_zone = EXCLUDED.time_zone,
+			quiet_from = EXCLUDED.quiet_from,
+			quiet_to = EXCLUDED.quiet_to`,
+		userEntity.ID, userEntity.WatchlistOnly, userEntity.TimeZone, userEntity.QuietFrom,
+		userEntity.QuietTo).Error; err != nil {
+		d.log.Error("error upsert user settings", d.log.ErrorC(err))
+		return err
+	}
+	return nil
+}
+
+func (d *PostresRepository) AddUserSymbols(id, list string, symbols []string) error {
+	column, err := symbolListColumn(list)
+	if err != nil {
+		return err
+	}
+	added := []string{}
+	for _, symbol := range symbols {
+		if !slices.Contains(added, symbol) {
+			added = append(added, symbol)
+		}
+	}
+	symbolsJSON, err := json.Marshal(added)
+	if err != nil {
+		return err
+	}
+
+	if err := d.client.Exec(fmt.Sprintf(`
+		INSERT INTO dwh_users (id, %[1]s) VALUES (?, ?::jsonb)
+		ON CONFLICT (id) DO UPDATE
+		SET %[1]s = dwh_users.%[1]s || COALESCE((
+			SELECT jsonb_agg(symbol ORDER BY position)
+			FROM jsonb_array_elements(EXCLUDED.%[1]s) WITH ORDINALITY AS added(symbol, position)
+			WHERE NOT dwh_users.%[1]s @> jsonb_build_array(symbol)), '[]'::jsonb)`, column),
+		id, string(symbolsJSON)).Error; err != nil {
+		d.log.Error("error add user symbols", d.log.ErrorC(err))
+		return err
+	}
+	return nil
+}
+
+func (d *PostresRepository) RemoveUserSymbols(id, list string, symbols []string) error {
+	column, err := symbolListColumn(list)
+	if err != nil {
+		return err
+	}
+	symbolsJSON, err := json.Marshal(symbols)
+	if err != nil {
+		return err
+	}
+
+	if err := d.client.Exec(fmt.Sprintf(`
+		UPDATE dwh_users SET %[1]s = %[1]s - ARRAY(SELECT jsonb_array_elements_text(?::jsonb))
+		WHERE id = ?`, column), string(symbolsJSON), id).Error; err != nil {
+		d.log.Error("error remove user symbols", d.log.ErrorC(err))
+		return err
+	}
+	return nil
+}
+
+func (d *PostresRepository) UpdateSessionQuietPending(id string, quietPending bool) (bool, error) {
+	result := d.client.Exec("UPDATE dwh_sessions SET quiet_pending = ? WHERE id = ? AND quiet_pending <> ?",
+		quietPending, id, quietPending)
+	if result.Error != nil {
+		d.log.Error("error update session quiet pending", d.log.ErrorC(result.Error))
+		return false, result.Error
+	}
+	return result.RowsAffected != 0, nil
+}
+
+func (d *PostresRepository) SelectClosedTransactions(id string) []entity.Transaction {
+	var closedTransactions closedTransactions
+
+	if err := d.client.Raw(`
+		UPDATE dwh_closed_transactions
+		SET is_notified = true
+		WHERE id = $1 AND is_posted = true AND is_notified = false
+		RETURNING id, symbol, chain, market_from, market_to, spread, peak_spread, created_at, closed_at`,
+		id).Scan(&closedTransactions).Error; err != nil {
+		d.log.Error("error select closed transactions", d.log.ErrorC(err))
+		return nil
+	}
+
+	return closedTransactions.toEntity()
+}
+
+func (d *PostresRepository) SelectSpreadHistory(id, symbol, chain, marketFrom, marketTo string,
+	since time.Time) []entity.SpreadPoint {
+	var spreadPoints spreadPoints
+
+	if err := d.client.Raw(`
+		SELECT spread, ask_cost, bid_cost, created_at
+		FROM dwh_spread_history
+		WHERE id = $1 AND symbol = $2 AND chain = $3 AND market_from = $4 AND market_to = $5 AND created_at >= $6
+		ORDER BY created_at`, id, symbol, chain, marketFrom, marketTo, since).Scan(&spreadPoints).Error; err != nil {
+		d.log.Error("error select spread history", d.log.ErrorC(err))
+		return nil
+	}
+
+	return spreadPoints.toEntity()
+}
+
+func (d *PostresRepository) StreamTransactions(id string, filter entity.ExportFilter,
+	handler func(transaction entity.Transaction) error) error {
+
+	rows, err := d.client.Raw(`
+		SELECT symbol, chain, market_from, market_to, COALESCE(spread, 0) AS spread,
+			COALESCE(peak_spread, spread, 0) AS peak_spread, COALESCE(with_draw_fee, 0) AS with_draw_fee,
+			COALESCE(amount_coin, 0) AS amount_coin, COALESCE(amount_ask_order, 0) AS amount_ask_order,
+			COALESCE(ask_cost, 0) AS ask_cost, COALESCE(amount_bid_order, 0) AS amount_bid_order,
+			COALESCE(bid_cost, 0) AS bid_cost, created_at, updated_at, closed_at
+		FROM (
+			SELECT symbol, chain, market_from, market_to, spread, peak_spread, with_draw_fee, amount_coin,
+				amount_ask_order, ask_cost, amount_bid_order, bid_cost, created_at, updated_at,
+				NULL::TIMESTAMP AS closed_at
+			FROM dwh_transactions
+			WHERE id = @id AND updated_at >= @since AND @status IN ('all', 'open')
+			UNION ALL
+			SELECT symbol, chain, market_from, market_to, spread, peak_spread, with_draw_fee, amount_coin,
+				amount_ask_order, ask_cost, amount_bid_order, bid_cost, created_at, updated_at, closed_at
+			FROM dwh_closed_transactions
+			WHERE id = @id AND closed_at >= @since AND @status IN ('all', 'closed')
+		) t
+		WHERE (@symbol = '' OR symbol = @symbol)
+			AND (@market_from = '' OR market_from = @market_from)
+			AND (@market_to = '' OR market_to = @market_to)
+			AND (@chain = '' OR chain = @chain)
+			AND COALESCE(spread, 0) >= @min_spread
+		ORDER BY created_at`, sql.Named("id", id), sql.Named("since", filter.Since),
+		sql.Named("status", filter.Status), sql.Named("symbol", filter.Symbol),
+		sql.Named("market_from", filter.MarketFrom), sql.Named("market_to", filter.MarketTo),
+		sql.Named("chain", filter.Chain), sql.Named("min_spread", filter.MinSpread)).Rows()
+	if err != nil {
+		d.log.Error("error stream transactions", d.log.ErrorC(err))
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var exportedTransaction exportedTransaction
+		if err := d.client.ScanRows(rows, &exportedTransaction); err != nil {
+			return err
+		}
+		if err := handler(exportedTransaction.toEntity()); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+func (d *PostresRepository) UpdateSessionNotifyClosed(id string, notifyClosed bool) error {
+	if err := d.client.Exec("UPDATE dwh_sessions SET notify_closed = ? WHERE id = ?", notifyClosed,
+		id).Error; err != nil {
+		d.log.Error("error update session notify closed", d.log.ErrorC(err))
+		return err
+	}
+	return nil
+}
+
+func (d *PostresRepository) InsertSchedule(scheduleEntity entity.Schedule) error {
+	weekdaysJSON, err := json.Marshal(scheduleEntity.Weekdays)
+	if err != nil {
+		return err
+	}
+
+	if err := d.client.Exec(`
+		INSERT INTO dwh_schedules (session_id, request, weekdays, start_at, stop_at)
+		VALUES (?, ?, ?, ?, ?)`, scheduleEntity.SessionID, scheduleEntity.Request, string(weekdaysJSON),
+		scheduleEntity.Start, scheduleEntity.Stop).Error; err != nil {
+		d.log.Error("error insert schedule", d.log.ErrorC(err))
+		return err
+	}
+	return nil
+}
+
+func (d *PostresRepository) SelectSchedules() []entity.Schedule {
+	var schedules schedules
+
+	if err := d.client.Raw(`
+		SELECT id, session_id, request, weekdays, start_at, stop_at
+		FROM dwh_schedules ORDER BY id`).Scan(&schedules).Error; err != nil {
+		d.log.Error("error select schedules", d.log.ErrorC(err))
+		return nil
+	}
+	return schedules.toEntity()
+}
+
+func (d *PostresRepository) SelectOwnerSchedules(prefix string) []entity.Schedule {
+	var schedules schedules
+
+	if err := d.client.Raw(`
+		SELECT id, session_id, request, weekdays, start_at, stop_at
+		FROM dwh_schedules WHERE starts_with(session_id, $1) ORDER BY id`, prefix).Scan(&schedules).Error; err != nil {
+		d.log.Error("error select schedules", d.log.ErrorC(err))
+		return nil
+	}
+	return schedules.toEntity()
+}
+
+func (d *PostresRepository) DeleteSchedule(prefix string, id int64) (entity.Schedule, bool) {
+	var schedules schedules
+
+	if err := d.client.Raw(`
+		DELETE FROM dwh_schedules WHERE starts_with(session_id, $1) AND id = $2
+		RETURNING id, session_id, request, weekdays, start_at, stop_at`, prefix, id).Scan(&schedules).Error; err != nil {
+		d.log.Error("error delete schedule", d.log.ErrorC(err))
+		return entity.Schedule{}, false
+	}
+	deleted := schedules.toEntity()
+	if len(deleted) == 0 {
+		return entity.Schedule{}, false
+	}
+	return deleted[0], true
+}
+
+func (d *PostresRepository) InsertFavorite(favoriteEntity entity.Favorite) error {
+	if err := d.client.Exec(`
+		INSERT INTO dwh_favorites (user_id, chat_id, symbol, chain, market_from, market_to, usdt, threshold,
+			spread, is_above, checked_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP)
+		ON CONFLICT (user_id, symbol, chain, market_from, market_to) DO UPDATE
+		SET chat_id = EXCLUDED.chat_id, usdt = EXCLUDED.usdt`, favoriteEntity.UserID, favoriteEntity.ChatID,
+		favoriteEntity.Symbol, favoriteEntity.Chain, favoriteEntity.MarketFrom, favoriteEntity.MarketTo,
+		favoriteEntity.USDT, favoriteEntity.Threshold, favoriteEntity.Spread,
+		favoriteEntity.IsAbove).Error; err != nil {
+		d.log.Error("error insert favorite", d.log.ErrorC(err))
+		return err
+	}
+	return nil
+}
+
+func (d *PostresRepository) SelectFavorites() []entity.Favorite {
+	var favorites favorites
+
+	if err := d.client.Raw("SELECT * FROM dwh_favorites ORDER BY id").Scan(&favorites).Error; err != nil {
+		d.log.Error("error select favorites", d.log.ErrorC(err))
+		return nil
+	}
+	return favorites.toEntity()
+}
+
+func (d *PostresRepository) SelectUserFavorites(userID string) []entity.Favorite {
+	var favorites favorites
+
+	if err := d.client.Raw("SELECT * FROM dwh_favorites WHERE user_id = $1 ORDER BY id",
+		userID).Scan(&favorites).Error; err != nil {
+		d.log.Error("error select favorites", d.log.ErrorC(err))
+		return nil
+	}
+	return favorites.toEntity()
+}
+
+func (d *PostresRepository) UpdateFavoriteThreshold(userID string, id int64, threshold float64) bool {
+	result := d.client.Exec("UPDATE dwh_favorites SET threshold = ? WHERE user_id = ? AND id = ?", threshold,
+		userID, id)
+	if result.Error != nil {
+		d.log.Error("error update favorite threshold", d.log.ErrorC(result.Error))
+		return false
+	}
+	return result.RowsAffected != 0
+}
+
+func (d *PostresRepository) UpdateFavoriteState(id int64, spread float64, isAbove bool) error {
+	if err := d.client.Exec(`
+		UPDATE dwh_favorites SET spread = ?, is_above = ?, checked_at = CURRENT_TIMESTAMP
+		WHERE id = ?`, spread, isAbove, id).Error; err != nil {
+		d.log.Error("error update favorite state", d.log.ErrorC(err))
+		return err
+	}
+	return nil
+}
+
+func (d *PostresRepository) DeleteFavorite(userID string, id int64) bool {
+	result := d.client.Exec("DELETE FROM dwh_favorites WHERE user_id = ? AND id = ?", userID, id)
+	if result.Error != nil {
+		d.log.Error("error delete favorite", d.log.ErrorC(result.Error))
+		return false
+	}
+	return result.RowsAffected != 0
+}
+
+func (d *PostresRepository) UpsertDigest(digestEntity entity.Digest) error {
+	if err := d.client.Exec(`
+		INSERT INTO dwh_digests (user_id, chat_id, period, at, weekday, sent_at) VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT (user_id) DO UPDATE
+		SET
+			chat_id = EXCLUDED.chat_id,
+			period = EXCLUDED.period,
+			at = EXCLUDED.at,
+			weekday = EXCLUDED.weekday,
+			sent_at = EXCLUDED.sent_at`, digestEntity.UserID, digestEntity.ChatID, digestEntity.Period,
+		digestEntity.At, int(digestEntity.Weekday), digestEntity.SentAt).Error; err != nil {
+		d.log.Error("error upsert digest", d.log.ErrorC(err))
+		return err
+	}
+	return nil
+}
+
+func (d *PostresRepository) SelectDigest(userID string) entity.Digest {
+	var digests digests
+
+	if err := d.client.Raw("SELECT * FROM dwh_digests WHERE user_id = $1", userID).Scan(&digests).Error; err != nil {
+		d.log.Error("error select digest", d.log.ErrorC(err))
+	}
+	if len(digests) == 0 {
+		return entity.Digest{UserID: userID}
+	}
+	return digests.toEntity()[0]
+}
+
+func (d *PostresRepository) SelectDigests() []entity.Digest {
+	var digests digests
+
+	if err := d.client.Raw("SELECT * FROM dwh_digests").Scan(&digests).Error; err != nil {
+		d.log.Error("error select digests", d.log.ErrorC(err))
+		return nil
+	}
+	return digests.toEntity()
+}
+
+func (d *PostresRepository) UpdateDigestSentAt(userID string, sentAt time.Time) error {
+	if err := d.client.Exec("UPDATE dwh_digests SET sent_at = ? WHERE user_id = ?", sentAt,
+		userID).Error; err != nil {
+		d.log.Error("error update digest", d.log.ErrorC(err))
+		return err
+	}
+	return nil
+}
+
+func (d *PostresRepository) DeleteDigest(userID string) error {
+	if err := d.client.Exec("DELETE FROM dwh_digests WHERE user_id = ?", userID).Error; err != nil {
+		d.log.Error("error delete digest", d.log.ErrorC(err))
+		return err
+	}
+	return nil
+}
+
+func (d *PostresRepository) SelectDigestTransactions(ownerID string, since time.Time) []entity.Transaction {
+	var exportedTransactions []exportedTransaction
+
+	if err := d.client.Raw(`
+		SELECT symbol, chain, market_from, market_to, COALESCE(spread, 0) AS spread,
+			COALESCE(peak_spread, spread, 0) AS peak_spread, created_at, closed_at
+		FROM (
+			SELECT symbol, chain, market_from, market_to, spread, peak_spread, created_at,
+				NULL::TIMESTAMP AS closed_at
+			FROM dwh_transactions
+			WHERE split_part(id, ':', 2) = $1 AND created_at >= $2
+			UNION ALL
+			SELECT symbol, chain, market_from, market_to, spread, peak_spread, created_at, closed_at
+			FROM dwh_closed_transactions
+			WHERE split_part(id, ':', 2) = $1 AND closed_at >= $2
+		) t`, ownerID, since).Scan(&exportedTransactions).Error; err != nil {
+		d.log.Error("error select digest transactions", d.log.ErrorC(err))
+		return nil
+	}
+
+	response := []entity.Transaction{}
+	for _, transaction := range exportedTransactions {
+		response = append(response, transaction.toEntity())
+	}
+	return response
+}
+
+func (d *PostresRepository) SelectChatThread(id string) int {
+	var threadID int
+
+	if err := d.client.Raw("SELECT thread_id FROM dwh_chats WHERE id = $1", id).Scan(&threadID).Error; err != nil {
+		d.log.Error("error select chat thread", d.log.ErrorC(err))
+	}
+	return threadID
+}
+
+func (d *PostresRepository) UpsertChatThread(id string, threadID int) error {
+	if err := d.client.Exec(`
+		INSERT INTO dwh_chats (id, thread_id) VALUES (?, ?)
+		ON CONFLICT (id) DO UPDATE SET thread_id = EXCLUDED.thread_id`, id, threadID).Error; err != nil {
+		d.log.Error("error upsert chat thread", d.log.ErrorC(err))
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package postgres
+
+import (
+	"crypto_pro/internal/domain/entity"
+	"crypto_pro/pkg/logger"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+func newMockRepository(t *testing.T) (*PostresRepository, sqlmock.Sqlmock) {
+	t.Helper()
+
+	conn, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	client, err := gorm.Open(postgres.New(postgres.Config{Conn: conn}), &gorm.Config{
+		Logger: gormlogger.Default.LogMode(gormlogger.Silent),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &PostresRepository{log: logger.New(false), client: client}, mock
+}
+
+func TestSelectPostedTransactions(t *testing.T) {
+	repository, mock := newMockRepository(t)
+	updatedAt := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery("FROM dwh_transactions").WithArgs("1:2:main").WillReturnRows(sqlmock.NewRows([]string{
+		"id", "symbol", "chain", "market_from", "market_to", "spread", "with_draw_fee", "withdraw_max",
+		"amount_coin", "amount_ask_order", "ask_cost", "ask_order", "amount_bid_order", "bid_cost", "bid_order",
+		"updated_at", "posted_spread", "posted_amount_ask_order", "posted_ask_cost", "posted_ask_order",
+		"posted_amount_bid_order", "posted_bid_cost", "posted_bid_order",
+	}).AddRow(
+		"1:2:main", "BTC", "TRC20", "Binance", "Bybit", 2.5, 0.1, 10.0,
+		3.0, 2.0, 300.0, []byte(`[{"Price":100,"Qty":3}]`), 1.0, 307.5, []byte(`[{"Price":102.5,"Qty":3}]`),
+		updatedAt, 1.5, 1.0, 295.0, []byte(`[{"Price":98,"Qty":3}]`),
+		1.0, 299.5, []byte(`[{"Price":99.5,"Qty":3}]`),
+	))
+
+	changes := repository.SelectPostedTransactions("1:2:main")
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 1 {
+		t.Fatalf("got %v changes, want 1", len(changes))
+	}
+
+	current := entity.Transaction{ID: "1:2:main", Symbol: "BTC", Chain: "TRC20", MarketFrom: "Binance",
+		MarketTo: "Bybit", Spread: 2.5, WithDrawFee: 0.1, WithdrawMax: 10, AmountCoin: 3, AmountAskOrder: 2,
+		AskCost: 300, AskOrder: []entity.Order{{Price: 100, Qty: 3}}, AmountBidOrder: 1, BidCost: 307.5,
+		BidOrder: []entity.Order{{Price: 102.5, Qty: 3}}, UpdatedAt: updatedAt}
+	previous := current
+	previous.Spread, previous.AmountAskOrder, previous.AskCost = 1.5, 1, 295
+	previous.AskOrder = []entity.Order{{Price: 98, Qty: 3}}
+	previous.AmountBidOrder, previous.BidCost = 1, 299.5
+	previous.BidOrder = []entity.Order{{Price: 99.5, Qty: 3}}
+
+	if !reflect.DeepEqual(changes[0].Current, current) {
+		t.Errorf("current = %+v, want %+v", changes[0].Current, current)
+	}
+	if !reflect.DeepEqual(changes[0].Previous, previous) {
+		t.Errorf("previous = %+v, want %+v", changes[0].Previous, previous)
+	}
+}
+
+func TestSelectSpreadHistory(t *testing.T) {
+	repository, mock := newMockRepository(t)
+	since := time.Date(2026, 10, 19, 6, 0, 0, 0, time.UTC)
+	first := time.Date(2026, 10, 19, 9, 0, 0, 0, time.FixedZone("MSK", 3*60*60))
+
+	mock.ExpectQuery("FROM dwh_spread_history").
+		WithArgs("1:2:main", "BTC", "TRC20", "BYBIT", "MEXC", since).
+		WillReturnRows(sqlmock.NewRows([]string{"spread", "ask_cost", "bid_cost", "created_at"}).
+			AddRow(1.5, 1000.0, 1015.0, first).
+			AddRow(1.2, 1001.0, 1013.0, first.Add(time.Minute)))
+
+	points := repository.SelectSpreadHistory("1:2:main", "BTC", "TRC20", "BYBIT", "MEXC", since)
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []entity.SpreadPoint{
+		{Spread: 1.5, AskCost: 1000, BidCost: 1015, CreatedAt: first},
+		{Spread: 1.2, AskCost: 1001, BidCost: 1013, CreatedAt: first.Add(time.Minute)},
+	}
+	if !reflect.DeepEqual(points, want) {
+		t.Errorf("points = %+v, want %+v", points, want)
+	}
+	if got := points[0].CreatedAt.UTC().Format("15:04"); got != "06:00" {
+		t.Errorf("first point in UTC = %v, want 06:00", got)
+	}
+}
@@ -0,0 +1,158 @@
+package postgres
+
+var schema = []string{
+	`CREATE TABLE IF NOT EXISTS raw_transactions (
+		id TEXT NOT NULL,
+		symbol TEXT NOT NULL,
+		chain TEXT NOT NULL,
+		market_from TEXT NOT NULL,
+		market_to TEXT NOT NULL,
+		spread DOUBLE PRECISION,
+		with_draw_fee DOUBLE PRECISION,
+		withdraw_max DOUBLE PRECISION,
+		amount_coin DOUBLE PRECISION,
+		amount_ask_order DOUBLE PRECISION,
+		ask_cost DOUBLE PRECISION,
+		ask_order JSONB,
+		amount_bid_order DOUBLE PRECISION,
+		bid_cost DOUBLE PRECISION,
+		bid_order JSONB,
+		updated_at TIMESTAMP
+	)`,
+	`CREATE TABLE IF NOT EXISTS dwh_transactions (
+		id TEXT NOT NULL,
+		symbol TEXT NOT NULL,
+		chain TEXT NOT NULL,
+		market_from TEXT NOT NULL,
+		market_to TEXT NOT NULL,
+		spread DOUBLE PRECISION,
+		with_draw_fee DOUBLE PRECISION,
+		withdraw_max DOUBLE PRECISION,
+		amount_coin DOUBLE PRECISION,
+		amount_ask_order DOUBLE PRECISION,
+		ask_cost DOUBLE PRECISION,
+		ask_order JSONB,
+		amount_bid_order DOUBLE PRECISION,
+		bid_cost DOUBLE PRECISION,
+		bid_order JSONB,
+		is_posted BOOLEAN NOT NULL DEFAULT false,
+		updated_at TIMESTAMP,
+		PRIMARY KEY (id, symbol, chain, market_from, market_to)
+	)`,
+	`CREATE TABLE IF NOT EXISTS dwh_sessions (
+		id TEXT PRIMARY KEY,
+		usdt NUMERIC,
+		spread_min NUMERIC,
+		spread_max NUMERIC
+	)`,
+	`ALTER TABLE dwh_sessions ADD COLUMN IF NOT EXISTS buy_markets JSONB NOT NULL DEFAULT '[]'`,
+	`ALTER TABLE dwh_sessions ADD COLUMN IF NOT EXISTS sell_markets JSONB NOT NULL DEFAULT '[]'`,
+	`ALTER TABLE dwh_sessions ADD COLUMN IF NOT EXISTS include_chains JSONB NOT NULL DEFAULT '[]'`,
+	`ALTER TABLE dwh_sessions ADD COLUMN IF NOT EXISTS exclude_chains JSONB NOT NULL DEFAULT '[]'`,
+	`ALTER TABLE dwh_sessions ADD COLUMN IF NOT EXISTS guards JSONB NOT NULL DEFAULT '{}'`,
+	`ALTER TABLE dwh_sessions ADD COLUMN IF NOT EXISTS alerts JSONB NOT NULL DEFAULT '{}'`,
+	`ALTER TABLE dwh_transactions ADD COLUMN IF NOT EXISTS posted_spread DOUBLE PRECISION`,
+	`ALTER TABLE dwh_transactions ADD COLUMN IF NOT EXISTS posted_amount_ask_order DOUBLE PRECISION`,
+	`ALTER TABLE dwh_transactions ADD COLUMN IF NOT EXISTS posted_ask_cost DOUBLE PRECISION`,
+	`ALTER TABLE dwh_transactions ADD COLUMN IF NOT EXISTS posted_ask_order JSONB`,
+	`ALTER TABLE dwh_transactions ADD COLUMN IF NOT EXISTS posted_amount_bid_order DOUBLE PRECISION`,
+	`ALTER TABLE dwh_transactions ADD COLUMN IF NOT EXISTS posted_bid_cost DOUBLE PRECISION`,
+	`ALTER TABLE dwh_transactions ADD COLUMN IF NOT EXISTS posted_bid_order JSONB`,
+	`ALTER TABLE dwh_sessions ADD COLUMN IF NOT EXISTS notify_closed BOOLEAN NOT NULL DEFAULT false`,
+	`ALTER TABLE dwh_transactions ADD COLUMN IF NOT EXISTS created_at TIMESTAMP`,
+	`ALTER TABLE dwh_transactions ADD COLUMN IF NOT EXISTS peak_spread DOUBLE PRECISION`,
+	`CREATE TABLE IF NOT EXISTS dwh_closed_transactions (
+		id TEXT NOT NULL,
+		symbol TEXT NOT NULL,
+		chain TEXT NOT NULL,
+		market_from TEXT NOT NULL,
+		market_to TEXT NOT NULL,
+		spread DOUBLE PRECISION,
+		peak_spread DOUBLE PRECISION,
+		is_posted BOOLEAN NOT NULL DEFAULT false,
+		is_notified BOOLEAN NOT NULL DEFAULT false,
+		created_at TIMESTAMP,
+		closed_at TIMESTAMP
+	)`,
+	`ALTER TABLE dwh_closed_transactions ADD COLUMN IF NOT EXISTS with_draw_fee DOUBLE PRECISION`,
+	`ALTER TABLE dwh_closed_transactions ADD COLUMN IF NOT EXISTS amount_coin DOUBLE PRECISION`,
+	`ALTER TABLE dwh_closed_transactions ADD COLUMN IF NOT EXISTS amount_ask_order DOUBLE PRECISION`,
+	`ALTER TABLE dwh_closed_transactions ADD COLUMN IF NOT EXISTS ask_cost DOUBLE PRECISION`,
+	`ALTER TABLE dwh_closed_transactions ADD COLUMN IF NOT EXISTS amount_bid_order DOUBLE PRECISION`,
+	`ALTER TABLE dwh_closed_transactions ADD COLUMN IF NOT EXISTS bid_cost DOUBLE PRECISION`,
+	`ALTER TABLE dwh_closed_transactions ADD COLUMN IF NOT EXISTS updated_at TIMESTAMP`,
+	`CREATE INDEX IF NOT EXISTS dwh_closed_transactions_id_idx ON dwh_closed_transactions (id, is_notified)`,
+	`CREATE INDEX IF NOT EXISTS dwh_closed_transactions_closed_at_idx ON dwh_closed_transactions (closed_at)`,
+	`CREATE INDEX IF NOT EXISTS dwh_closed_transactions_owner_idx ON dwh_closed_transactions
+		(split_part(id, ':', 2), closed_at)`,
+	`CREATE INDEX IF NOT EXISTS dwh_transactions_owner_idx ON dwh_transactions (split_part(id, ':', 2), created_at)`,
+	`CREATE TABLE IF NOT EXISTS dwh_users (
+		id TEXT PRIMARY KEY,
+		blacklist JSONB NOT NULL DEFAULT '[]',
+		watchlist JSONB NOT NULL DEFAULT '[]',
+		watchlist_only BOOLEAN NOT NULL DEFAULT false
+	)`,
+	`ALTER TABLE dwh_users ADD COLUMN IF NOT EXISTS time_zone TEXT NOT NULL DEFAULT ''`,
+	`ALTER TABLE dwh_users ADD COLUMN IF NOT EXISTS quiet_from TEXT NOT NULL DEFAULT ''`,
+	`ALTER TABLE dwh_users ADD COLUMN IF NOT EXISTS quiet_to TEXT NOT NULL DEFAULT ''`,
+	`ALTER TABLE dwh_sessions ADD COLUMN IF NOT EXISTS quiet_pending BOOLEAN NOT NULL DEFAULT false`,
+	`CREATE TABLE IF NOT EXISTS dwh_chats (
+		id TEXT PRIMARY KEY,
+		thread_id BIGINT NOT NULL DEFAULT 0
+	)`,
+	`CREATE TABLE IF NOT EXISTS dwh_spread_history (
+		id TEXT NOT NULL,
+		symbol TEXT NOT NULL,
+		market_from TEXT NOT NULL,
+		market_to TEXT NOT NULL,
+		spread DOUBLE PRECISION,
+		ask_cost DOUBLE PRECISION,
+		bid_cost DOUBLE PRECISION,
+		created_at TIMESTAMPTZ NOT NULL
+	)`,
+	`ALTER TABLE dwh_spread_history ALTER COLUMN created_at TYPE TIMESTAMPTZ`,
+	`ALTER TABLE dwh_spread_history ADD COLUMN IF NOT EXISTS chain TEXT NOT NULL DEFAULT ''`,
+	`DROP INDEX IF EXISTS dwh_spread_history_pair_idx`,
+	`CREATE INDEX IF NOT EXISTS dwh_spread_history_chain_idx ON dwh_spread_history (id, symbol, chain,
+		market_from, market_to, created_at)`,
+	`CREATE TABLE IF NOT EXISTS dwh_favorites (
+		id BIGSERIAL PRIMARY KEY,
+		user_id TEXT NOT NULL,
+		chat_id TEXT NOT NULL,
+		symbol TEXT NOT NULL,
+		chain TEXT NOT NULL,
+		market_from TEXT NOT NULL,
+		market_to TEXT NOT NULL,
+		usdt DOUBLE PRECISION NOT NULL,
+		threshold DOUBLE PRECISION NOT NULL,
+		spread DOUBLE PRECISION NOT NULL DEFAULT 0,
+		is_above BOOLEAN NOT NULL DEFAULT false,
+		UNIQUE (user_id, symbol, chain, market_from, market_to)
+	)`,
+	`ALTER TABLE dwh_favorites ADD COLUMN IF NOT EXISTS checked_at TIMESTAMPTZ`,
+	`CREATE TABLE IF NOT EXISTS dwh_digests (
+		user_id TEXT PRIMARY KEY,
+		chat_id TEXT NOT NULL,
+		period TEXT NOT NULL,
+		at TEXT NOT NULL,
+		weekday INT NOT NULL DEFAULT 0,
+		sent_at TIMESTAMPTZ NOT NULL
+	)`,
+	`CREATE TABLE IF NOT EXISTS dwh_schedules (
+		id BIGSERIAL PRIMARY KEY,
+		session_id TEXT NOT NULL,
+		request TEXT NOT NULL,
+		weekdays JSONB NOT NULL DEFAULT '[]',
+		start_at TEXT NOT NULL,
+		stop_at TEXT NOT NULL
+	)`,
+}
+
+func (d *PostresRepository) migrate() error {
+	for _, query := range schema {
+		if err := d.client.Exec(query).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
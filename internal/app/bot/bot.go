@@ -34,8 +34,11 @@ func (a App) Run() error {
 
 	a.log.Info("Init controller")
 	a.serviceProvider.setTelegramController()
+	a.serviceProvider.setAPIController()
 
 	a.log.Info("All layers was init, run tasks")
+	go a.runDigestScheduler()
+	go a.serviceProvider.apiController.Run(a.ctx)
 	a.serviceProvider.telegramController.Run(a.ctx)
 
 	a.log.Info("Have a nice day!")
@@ -0,0 +1,27 @@
+package bot
+
+import (
+	"time"
+)
+
+func (a App) runDigestScheduler() {
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-a.ctx.Done():
+			return
+		case now := <-ticker.C:
+			for _, report := range a.serviceProvider.taskUseCase.CheckDigests(now) {
+				if err := a.serviceProvider.telegramController.SendMessage(report.ChatID, report.Text); err != nil {
+					a.log.Error("failed to send digest", a.log.ErrorC(err))
+					continue
+				}
+				if err := a.serviceProvider.taskUseCase.MarkDigestSent(report.UserID, now); err != nil {
+					a.log.Error("failed to mark digest sent", a.log.ErrorC(err))
+				}
+			}
+		}
+	}
+}
@@ -4,11 +4,17 @@ import (
 	"context"
 	"crypto_pro/internal/adapters"
 	"crypto_pro/internal/adapters/postgres"
+	"crypto_pro/internal/chart"
 	"crypto_pro/internal/controller"
+	"crypto_pro/internal/controller/api"
+	"crypto_pro/internal/controller/grpc"
 	"crypto_pro/internal/controller/http"
 	"crypto_pro/internal/controller/telegram"
+	"crypto_pro/internal/controller/validation"
 	"crypto_pro/internal/domain/usecase"
 	"crypto_pro/internal/domain/usecase/task"
+	"crypto_pro/internal/templates"
+	"fmt"
 
 	"crypto_pro/pkg/logger"
 
@@ -22,6 +28,7 @@ type serviceProvider struct {
 	serverController   controller.Server
 	dbAdapter          adapters.DbAdapter
 	telegramController controller.TelegramController
+	apiController      controller.APIController
 	taskUseCase        usecase.TaskUseCase
 }
 
@@ -35,12 +42,28 @@ func newServiceProvider(ctx context.Context, log logger.Logger, cfg viper.Viper)
 
 func (s *serviceProvider) setServerController() controller.Server {
 	if s.serverController == nil {
-		serverController := http.New(s.cfg, s.log, s.taskUseCase)
-		s.serverController = serverController
+		var serverController controller.Server
+		switch transport := s.cfg.GetString("spot.transport"); transport {
+		case "", "http":
+			serverController = http.New(s.ctx, s.cfg, s.log, s.taskUseCase)
+		case "grpc":
+			serverController = grpc.New(s.ctx, s.cfg, s.log)
+		default:
+			panic(fmt.Sprintf("unknown spot transport %v", transport))
+		}
+		s.serverController = validation.New(serverController, s.getQuarantineLogger(),
+			s.cfg.GetDuration("spot.quarantine_interval"))
 	}
 	return s.serverController
 }
 
+func (s *serviceProvider) getQuarantineLogger() logger.Logger {
+	if filename := s.cfg.GetString("spot.quarantine_log"); filename != "" {
+		return logger.NewFile(filename)
+	}
+	return s.log
+}
+
 func (s *serviceProvider) setDBAdapter() adapters.DbAdapter {
 	if s.dbAdapter == nil {
 		dbAdapter := postgres.New(s.ctx, s.cfg, s.log)
@@ -51,7 +74,9 @@ func (s *serviceProvider) setDBAdapter() adapters.DbAdapter {
 
 func (s *serviceProvider) setTaskUseCase() usecase.TaskUseCase {
 	if s.taskUseCase == nil {
-		taskUseCase := task.New(s.log, s.serverController, s.dbAdapter)
+		taskUseCase := task.New(s.log, s.serverController, s.dbAdapter, templates.New(s.cfg, s.log),
+			chart.New(s.cfg), task.NewSpotFetcher(s.serverController, s.cfg.GetDuration("spot.share_ttl"),
+				s.cfg.GetFloat64("spot.share_max_width")))
 		s.taskUseCase = taskUseCase
 	}
 	return s.taskUseCase
@@ -64,3 +89,11 @@ func (s *serviceProvider) setTelegramController() controller.TelegramController
 	}
 	return s.telegramController
 }
+
+func (s *serviceProvider) setAPIController() controller.APIController {
+	if s.apiController == nil {
+		apiController := api.New(s.cfg, s.log, s.taskUseCase)
+		s.apiController = apiController
+	}
+	return s.apiController
+}
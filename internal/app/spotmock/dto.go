@@ -0,0 +1,76 @@
+package spotmock
+
+import (
+	"crypto_pro/internal/domain/entity"
+
+	spotgrpc "crypto_pro/internal/controller/grpc"
+	spothttp "crypto_pro/internal/controller/http"
+)
+
+func transactionsToHTTP(transactions []entity.Transaction) spothttp.Transactions {
+	response := spothttp.Transactions{}
+	for _, val := range transactions {
+		response = append(response, spothttp.Transaction{
+			Symbol:         val.Symbol,
+			Chain:          val.Chain,
+			MarketFrom:     val.MarketFrom,
+			MarketTo:       val.MarketTo,
+			Spread:         val.Spread,
+			WithdrawFee:    val.WithDrawFee,
+			WithdrawMax:    val.WithdrawMax,
+			AmountCoin:     val.AmountCoin,
+			AmountAskOrder: val.AmountAskOrder,
+			AskCost:        val.AskCost,
+			AskOrder:       ordersToHTTP(val.AskOrder),
+			AmountBidOrder: val.AmountBidOrder,
+			BidCost:        val.BidCost,
+			BidOrder:       ordersToHTTP(val.BidOrder),
+		})
+	}
+	return response
+}
+
+func ordersToHTTP(orders []entity.Order) []spothttp.Order {
+	response := []spothttp.Order{}
+	for _, val := range orders {
+		response = append(response, spothttp.Order{
+			Price: val.Price,
+			Qty:   val.Qty,
+		})
+	}
+	return response
+}
+
+func transactionsToGRPC(transactions []entity.Transaction) []*spotgrpc.Transaction {
+	response := []*spotgrpc.Transaction{}
+	for _, val := range transactions {
+		response = append(response, &spotgrpc.Transaction{
+			Symbol:         val.Symbol,
+			Chain:          val.Chain,
+			MarketFrom:     val.MarketFrom,
+			MarketTo:       val.MarketTo,
+			Spread:         val.Spread,
+			WithdrawFee:    val.WithDrawFee,
+			WithdrawMax:    val.WithdrawMax,
+			AmountCoin:     val.AmountCoin,
+			AmountAskOrder: val.AmountAskOrder,
+			AskCost:        val.AskCost,
+			AskOrder:       ordersToGRPC(val.AskOrder),
+			AmountBidOrder: val.AmountBidOrder,
+			BidCost:        val.BidCost,
+			BidOrder:       ordersToGRPC(val.BidOrder),
+		})
+	}
+	return response
+}
+
+func ordersToGRPC(orders []entity.Order) []*spotgrpc.Order {
+	response := []*spotgrpc.Order{}
+	for _, val := range orders {
+		response = append(response, &spotgrpc.Order{
+			Price: val.Price,
+			Qty:   val.Qty,
+		})
+	}
+	return response
+}
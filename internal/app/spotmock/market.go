@@ -0,0 +1,225 @@
+package spotmock
+
+import (
+	"cmp"
+	"crypto_pro/internal/domain/entity"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"sync"
+)
+
+const (
+	bookLevels       = 20
+	bookHalfSpread   = 0.0005
+	bookLevelStep    = 0.0004
+	bookLevelUSDT    = 1500.0
+	listingChance    = 0.8
+	shockChance      = 0.003
+	shockMinPercent  = 0.8
+	shockMaxPercent  = 6.0
+	shockMinTicks    = 5
+	shockMaxTicks    = 60
+	fairVolatility   = 0.002
+	offsetVolatility = 0.0008
+	offsetReversion  = 0.9
+)
+
+type coin struct {
+	symbol      string
+	chain       string
+	price       float64
+	withdrawFee float64
+	withdrawMax float64
+}
+
+var coins = []coin{
+	{symbol: "BTC", chain: "BTC", price: 65000, withdrawFee: 0.0002, withdrawMax: 5},
+	{symbol: "ETH", chain: "ERC20", price: 3200, withdrawFee: 0.002, withdrawMax: 100},
+	{symbol: "SOL", chain: "SOL", price: 150, withdrawFee: 0.01, withdrawMax: 5000},
+	{symbol: "TON", chain: "TON", price: 5.5, withdrawFee: 0.05, withdrawMax: 100000},
+	{symbol: "DOGE", chain: "DOGE", price: 0.12, withdrawFee: 5, withdrawMax: 10000000},
+	{symbol: "XRP", chain: "XRP", price: 0.5, withdrawFee: 0.25, withdrawMax: 1000000},
+	{symbol: "TRX", chain: "TRC20", price: 0.12, withdrawFee: 1, withdrawMax: 10000000},
+	{symbol: "LTC", chain: "LTC", price: 70, withdrawFee: 0.001, withdrawMax: 5000},
+	{symbol: "ADA", chain: "ADA", price: 0.45, withdrawFee: 1, withdrawMax: 1000000},
+	{symbol: "NOT", chain: "TON", price: 0.008, withdrawFee: 20, withdrawMax: 100000000},
+	{symbol: "VENOM", chain: "VENOM", price: 0.15, withdrawFee: 1, withdrawMax: 1000000},
+	{symbol: "USDC", chain: "BEP20", price: 1, withdrawFee: 0.3, withdrawMax: 1000000},
+}
+
+type quote struct {
+	offset     float64
+	depth      float64
+	shock      float64
+	shockTicks int
+	asks       []entity.Order
+	bids       []entity.Order
+}
+
+type Market struct {
+	mu     sync.RWMutex
+	random *rand.Rand
+	tick   int64
+	fair   map[string]float64
+	quotes map[string]map[string]*quote
+}
+
+func NewMarket(seed uint64) *Market {
+	market := &Market{random: rand.New(rand.NewPCG(seed, seed^0x9e3779b97f4a7c15)),
+		fair: map[string]float64{}, quotes: map[string]map[string]*quote{}}
+
+	for _, coin := range coins {
+		market.fair[coin.symbol] = coin.price
+	}
+	for _, name := range entity.Markets {
+		market.quotes[name] = map[string]*quote{}
+		for _, coin := range coins {
+			if market.random.Float64() > listingChance {
+				continue
+			}
+			market.quotes[name][coin.symbol] = &quote{offset: market.random.NormFloat64() * offsetVolatility,
+				depth: 0.3 + market.random.Float64()*2}
+		}
+	}
+	market.Step()
+	return market
+}
+
+func (m *Market) Tick() int64 {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.tick
+}
+
+func (m *Market) Step() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.tick++
+	for symbol, price := range m.fair {
+		m.fair[symbol] = price * math.Exp(m.random.NormFloat64()*fairVolatility)
+	}
+
+	for _, quotes := range m.quotes {
+		for symbol, quote := range quotes {
+			quote.offset = quote.offset*offsetReversion + m.random.NormFloat64()*offsetVolatility
+			if quote.shockTicks > 0 {
+				quote.shockTicks--
+			} else {
+				quote.shock = 0
+			}
+			if quote.shock == 0 && m.random.Float64() < shockChance {
+				percent := shockMinPercent + m.random.Float64()*(shockMaxPercent-shockMinPercent)
+				if m.random.IntN(2) == 0 {
+					percent = -percent
+				}
+				quote.shock = percent / 100
+				quote.shockTicks = shockMinTicks + m.random.IntN(shockMaxTicks-shockMinTicks)
+			}
+			m.fillBook(quote, m.fair[symbol]*(1+quote.offset+quote.shock))
+		}
+	}
+}
+
+func (m *Market) fillBook(quote *quote, mid float64) {
+	quote.asks, quote.bids = quote.asks[:0], quote.bids[:0]
+	for level := 0; level < bookLevels; level++ {
+		step := bookHalfSpread + float64(level)*bookLevelStep
+		askPrice, bidPrice := mid*(1+step), mid*(1-step)
+		quote.asks = append(quote.asks, entity.Order{Price: askPrice,
+			Qty: bookLevelUSDT * quote.depth * (0.5 + m.random.Float64()) / askPrice})
+		quote.bids = append(quote.bids, entity.Order{Price: bidPrice,
+			Qty: bookLevelUSDT * quote.depth * (0.5 + m.random.Float64()) / bidPrice})
+	}
+}
+
+func (m *Market) Deals(usdt, spreadMin, spreadMax float64, symbol, marketFrom,
+	marketTo string) []entity.Transaction {
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	deals := []entity.Transaction{}
+	for _, coin := range coins {
+		if symbol != "" && coin.symbol != symbol {
+			continue
+		}
+		for _, from := range entity.Markets {
+			if marketFrom != "" && from != marketFrom {
+				continue
+			}
+			ask, listed := m.quotes[from][coin.symbol]
+			if !listed {
+				continue
+			}
+			for _, to := range entity.Markets {
+				if to == from || (marketTo != "" && to != marketTo) {
+					continue
+				}
+				bid, listed := m.quotes[to][coin.symbol]
+				if !listed {
+					continue
+				}
+
+				deal, ok := m.deal(coin, from, to, ask.asks, bid.bids, usdt)
+				if ok && deal.Spread >= spreadMin && deal.Spread <= spreadMax {
+					deals = append(deals, deal)
+				}
+			}
+		}
+	}
+
+	slices.SortFunc(deals, func(a, b entity.Transaction) int {
+		return cmp.Compare(b.Spread, a.Spread)
+	})
+	return deals
+}
+
+func (m *Market) deal(coin coin, from, to string, asks, bids []entity.Order,
+	usdt float64) (entity.Transaction, bool) {
+
+	askOrders, amountCoin, askCost := []entity.Order{}, 0.0, 0.0
+	for _, ask := range asks {
+		if askCost >= usdt {
+			break
+		}
+		qty := min(ask.Qty, (usdt-askCost)/ask.Price)
+		askOrders = append(askOrders, entity.Order{Price: ask.Price, Qty: qty})
+		amountCoin += qty
+		askCost += qty * ask.Price
+	}
+
+	sellable := min(amountCoin-coin.withdrawFee, coin.withdrawMax)
+	if sellable <= 0 {
+		return entity.Transaction{}, false
+	}
+
+	bidOrders, sold, bidCost := []entity.Order{}, 0.0, 0.0
+	for _, bid := range bids {
+		if sold >= sellable {
+			break
+		}
+		qty := min(bid.Qty, sellable-sold)
+		bidOrders = append(bidOrders, entity.Order{Price: bid.Price, Qty: qty})
+		sold += qty
+		bidCost += qty * bid.Price
+	}
+
+	return entity.Transaction{
+		Symbol:         coin.symbol,
+		Chain:          coin.chain,
+		MarketFrom:     from,
+		MarketTo:       to,
+		Spread:         (bidCost - askCost) / askCost * 100,
+		WithDrawFee:    coin.withdrawFee,
+		WithdrawMax:    coin.withdrawMax,
+		AmountCoin:     amountCoin,
+		AmountAskOrder: float64(len(askOrders)),
+		AskCost:        askCost,
+		AskOrder:       askOrders,
+		AmountBidOrder: float64(len(bidOrders)),
+		BidCost:        bidCost,
+		BidOrder:       bidOrders,
+	}, true
+}
@@ -0,0 +1,67 @@
+package spotmock
+
+import (
+	"math"
+	"testing"
+)
+
+func TestMarketDeals(t *testing.T) {
+	market := NewMarket(42)
+	all := market.Deals(1000, math.Inf(-1), math.Inf(1), "", "", "")
+	if len(all) == 0 {
+		t.Fatal("Deals returned no deals")
+	}
+	for i := 1; i < len(all); i++ {
+		if all[i].Spread > all[i-1].Spread {
+			t.Fatalf("deals are not sorted by spread: %v after %v", all[i].Spread, all[i-1].Spread)
+		}
+	}
+	for _, deal := range all {
+		if deal.MarketFrom == deal.MarketTo {
+			t.Fatalf("deal %v has the same market %v on both sides", deal.Symbol, deal.MarketFrom)
+		}
+		if deal.AskCost > 1000+1e-6 {
+			t.Fatalf("deal %v ask cost %v exceeds 1000 USDT", deal.Symbol, deal.AskCost)
+		}
+	}
+
+	first := all[0]
+	tests := []struct {
+		name       string
+		spreadMin  float64
+		spreadMax  float64
+		symbol     string
+		marketFrom string
+		marketTo   string
+	}{
+		{name: "symbol", spreadMin: math.Inf(-1), spreadMax: math.Inf(1), symbol: first.Symbol},
+		{name: "market from", spreadMin: math.Inf(-1), spreadMax: math.Inf(1), marketFrom: first.MarketFrom},
+		{name: "market to", spreadMin: math.Inf(-1), spreadMax: math.Inf(1), marketTo: first.MarketTo},
+		{name: "route", spreadMin: math.Inf(-1), spreadMax: math.Inf(1), symbol: first.Symbol,
+			marketFrom: first.MarketFrom, marketTo: first.MarketTo},
+		{name: "spread bounds", spreadMin: first.Spread, spreadMax: first.Spread},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			deals := market.Deals(1000, test.spreadMin, test.spreadMax, test.symbol, test.marketFrom,
+				test.marketTo)
+			if len(deals) == 0 {
+				t.Fatal("Deals returned no deals")
+			}
+			for _, deal := range deals {
+				if (test.symbol != "" && deal.Symbol != test.symbol) ||
+					(test.marketFrom != "" && deal.MarketFrom != test.marketFrom) ||
+					(test.marketTo != "" && deal.MarketTo != test.marketTo) ||
+					deal.Spread < test.spreadMin || deal.Spread > test.spreadMax {
+					t.Errorf("deal %v %v → %v with spread %v does not match the filter", deal.Symbol,
+						deal.MarketFrom, deal.MarketTo, deal.Spread)
+				}
+			}
+		})
+	}
+
+	if deals := market.Deals(1000, math.Inf(-1), math.Inf(1), "UNKNOWN", "", ""); len(deals) != 0 {
+		t.Errorf("Deals for unknown symbol = %v, want none", deals)
+	}
+}
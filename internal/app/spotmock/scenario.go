@@ -0,0 +1,120 @@
+package spotmock
+
+import (
+	"crypto_pro/internal/domain/entity"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"sync"
+	"time"
+)
+
+const (
+	ScenarioOK        = "ok"
+	ScenarioEmpty     = "empty"
+	ScenarioTimeout   = "timeout"
+	ScenarioMalformed = "malformed"
+	ScenarioError     = "error"
+	ScenarioInvalid   = "invalid"
+	ScenarioFlaky     = "flaky"
+	ScenarioScript    = "script"
+)
+
+var Scenarios = []string{ScenarioOK, ScenarioEmpty, ScenarioTimeout, ScenarioMalformed, ScenarioError,
+	ScenarioInvalid, ScenarioFlaky}
+
+var flakyScenarios = []string{ScenarioTimeout, ScenarioMalformed, ScenarioError, ScenarioInvalid}
+
+type step struct {
+	Name     string        `mapstructure:"name"`
+	Duration time.Duration `mapstructure:"duration"`
+}
+
+type script struct {
+	mu        sync.Mutex
+	steps     []step
+	startedAt time.Time
+	override  string
+	flakyRate float64
+}
+
+func newScript(steps []step, flakyRate float64) *script {
+	for _, step := range steps {
+		if !slices.Contains(Scenarios, step.Name) || step.Duration <= 0 {
+			panic("invalid spotmock scenario step " + step.Name)
+		}
+	}
+	return &script{steps: steps, startedAt: time.Now(), flakyRate: flakyRate}
+}
+
+func (s *script) set(name string) bool {
+	if name != ScenarioScript && !slices.Contains(Scenarios, name) {
+		return false
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.override = ""
+	if name != ScenarioScript {
+		s.override = name
+	}
+	s.startedAt = time.Now()
+	return true
+}
+
+func (s *script) current(now time.Time) string {
+	name := s.name(now)
+	if name == ScenarioFlaky {
+		if rand.Float64() >= s.flakyRate {
+			return ScenarioOK
+		}
+		return flakyScenarios[rand.IntN(len(flakyScenarios))]
+	}
+	return name
+}
+
+func (s *script) name(now time.Time) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.override != "" {
+		return s.override
+	}
+	return s.scripted(now)
+}
+
+func (s *script) scripted(now time.Time) string {
+	if len(s.steps) == 0 {
+		return ScenarioOK
+	}
+
+	total := time.Duration(0)
+	for _, step := range s.steps {
+		total += step.Duration
+	}
+	elapsed := now.Sub(s.startedAt) % total
+	for _, step := range s.steps {
+		if elapsed < step.Duration {
+			return step.Name
+		}
+		elapsed -= step.Duration
+	}
+	return ScenarioOK
+}
+
+func corrupt(deals []entity.Transaction) []entity.Transaction {
+	for i := range deals {
+		switch i % 5 {
+		case 0:
+			deals[i].Spread = -math.Abs(deals[i].Spread) - 1
+		case 1:
+			deals[i].AskOrder = []entity.Order{}
+		case 2:
+			deals[i].MarketTo = deals[i].MarketFrom
+		case 3:
+			if len(deals[i].BidOrder) != 0 {
+				deals[i].BidOrder[0].Price = 0
+			}
+		}
+	}
+	return deals
+}
@@ -0,0 +1,119 @@
+package spotmock
+
+import (
+	"crypto_pro/internal/domain/entity"
+	"testing"
+	"time"
+)
+
+func TestScriptScripted(t *testing.T) {
+	start := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	steps := []step{{Name: ScenarioOK, Duration: time.Minute}, {Name: ScenarioError, Duration: 30 * time.Second},
+		{Name: ScenarioEmpty, Duration: 2 * time.Minute}}
+
+	tests := []struct {
+		name    string
+		steps   []step
+		elapsed time.Duration
+		want    string
+	}{
+		{name: "first step", steps: steps, want: ScenarioOK},
+		{name: "second step start inclusive", steps: steps, elapsed: time.Minute, want: ScenarioError},
+		{name: "third step", steps: steps, elapsed: 2 * time.Minute, want: ScenarioEmpty},
+		{name: "loops after last step", steps: steps, elapsed: 3*time.Minute + 30*time.Second, want: ScenarioOK},
+		{name: "loops into second step", steps: steps, elapsed: 8*time.Minute + 10*time.Second,
+			want: ScenarioError},
+		{name: "no steps", want: ScenarioOK},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			script := newScript(test.steps, 0)
+			script.startedAt = start
+			if got := script.scripted(start.Add(test.elapsed)); got != test.want {
+				t.Errorf("scripted(+%v) = %v, want %v", test.elapsed, got, test.want)
+			}
+		})
+	}
+}
+
+func TestScriptSet(t *testing.T) {
+	script := newScript([]step{{Name: ScenarioError, Duration: time.Minute},
+		{Name: ScenarioOK, Duration: time.Minute}}, 0)
+
+	if !script.set(ScenarioEmpty) {
+		t.Fatalf("set(%v) = false", ScenarioEmpty)
+	}
+	if got := script.name(time.Now().Add(90 * time.Second)); got != ScenarioEmpty {
+		t.Errorf("name with override = %v, want %v", got, ScenarioEmpty)
+	}
+
+	if script.set("unknown") {
+		t.Error("set(unknown) = true")
+	}
+	if got := script.name(time.Now()); got != ScenarioEmpty {
+		t.Errorf("name after unknown scenario = %v, want %v", got, ScenarioEmpty)
+	}
+
+	if !script.set(ScenarioScript) {
+		t.Fatalf("set(%v) = false", ScenarioScript)
+	}
+	now := time.Now()
+	if got := script.name(now); got != ScenarioError {
+		t.Errorf("name after resume = %v, want script restarted at %v", got, ScenarioError)
+	}
+	if got := script.name(now.Add(90 * time.Second)); got != ScenarioOK {
+		t.Errorf("name after resume +90s = %v, want %v", got, ScenarioOK)
+	}
+}
+
+func TestScriptCurrentFlaky(t *testing.T) {
+	tests := []struct {
+		name      string
+		flakyRate float64
+		failing   bool
+	}{
+		{name: "never fails", flakyRate: 0},
+		{name: "always fails", flakyRate: 1, failing: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			script := newScript(nil, test.flakyRate)
+			script.set(ScenarioFlaky)
+			for i := 0; i < 20; i++ {
+				got := script.current(time.Now())
+				if failing := got != ScenarioOK; failing != test.failing {
+					t.Fatalf("current() = %v, want failing %v", got, test.failing)
+				}
+			}
+		})
+	}
+}
+
+func TestCorrupt(t *testing.T) {
+	deals := []entity.Transaction{}
+	for i := 0; i < 5; i++ {
+		deals = append(deals, entity.Transaction{Symbol: "BTC", MarketFrom: "BYBIT", MarketTo: "MEXC",
+			Spread: 1.5, AskOrder: []entity.Order{{Price: 100, Qty: 1}},
+			BidOrder: []entity.Order{{Price: 101.5, Qty: 1}}})
+	}
+
+	deals = corrupt(deals)
+	if deals[0].Spread >= 0 {
+		t.Errorf("deal 0 spread = %v, want negative", deals[0].Spread)
+	}
+	if len(deals[1].AskOrder) != 0 {
+		t.Errorf("deal 1 ask orders = %v, want empty", deals[1].AskOrder)
+	}
+	if deals[2].MarketTo != deals[2].MarketFrom {
+		t.Errorf("deal 2 markets = %v → %v, want equal", deals[2].MarketFrom, deals[2].MarketTo)
+	}
+	if deals[3].BidOrder[0].Price != 0 {
+		t.Errorf("deal 3 bid price = %v, want 0", deals[3].BidOrder[0].Price)
+	}
+	if deals[4].Spread != 1.5 || len(deals[4].AskOrder) != 1 || deals[4].MarketTo != "MEXC" ||
+		deals[4].BidOrder[0].Price != 101.5 {
+		t.Errorf("deal 4 = %+v, want unchanged", deals[4])
+	}
+}
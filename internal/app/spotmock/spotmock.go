@@ -0,0 +1,294 @@
+package spotmock
+
+import (
+	"context"
+	"crypto_pro/pkg/logger"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	spotgrpc "crypto_pro/internal/controller/grpc"
+
+	"github.com/spf13/viper"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultAddress      = ":8080"
+	defaultTick         = 2 * time.Second
+	defaultTimeoutDelay = 30 * time.Second
+	defaultFlakyRate    = 0.3
+	malformedJSON       = `[{"Symbol":"BTC","Chain":"BTC","Spread":`
+)
+
+type App struct {
+	ctx          context.Context
+	log          logger.Logger
+	cfg          viper.Viper
+	market       *Market
+	script       *script
+	tick         time.Duration
+	timeoutDelay time.Duration
+}
+
+type spotServer struct {
+	spotgrpc.UnimplementedSpotServer
+	app App
+}
+
+func NewApp(ctx context.Context, log logger.Logger, cfg viper.Viper) App {
+	steps := []step{}
+	if err := cfg.UnmarshalKey("scenarios", &steps); err != nil {
+		panic(err)
+	}
+	flakyRate := defaultFlakyRate
+	if cfg.IsSet("flaky_rate") {
+		flakyRate = cfg.GetFloat64("flaky_rate")
+	}
+
+	app := App{ctx: ctx, log: log, cfg: cfg, market: NewMarket(cfg.GetUint64("seed")),
+		script: newScript(steps, flakyRate), tick: cfg.GetDuration("tick"),
+		timeoutDelay: cfg.GetDuration("timeout_delay")}
+	if app.tick <= 0 {
+		app.tick = defaultTick
+	}
+	if app.timeoutDelay <= 0 {
+		app.timeoutDelay = defaultTimeoutDelay
+	}
+	return app
+}
+
+func (a App) Run() error {
+	go a.runMarket()
+	if address := a.cfg.GetString("grpc_address"); address != "" {
+		go a.runGRPC(address)
+	}
+
+	address := a.cfg.GetString("address")
+	if address == "" {
+		address = defaultAddress
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /spot", a.getSpot)
+	mux.HandleFunc("GET /spot/stream", a.streamSpot)
+	mux.HandleFunc("GET /scenario", a.getScenario)
+	mux.HandleFunc("POST /scenario", a.setScenario)
+
+	server := &http.Server{Addr: address, Handler: mux}
+	go func() {
+		<-a.ctx.Done()
+		server.Close()
+	}()
+
+	a.log.Info("spot mock is listening", a.log.StringC("Address", address))
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+func (a App) runMarket() {
+	ticker := time.NewTicker(a.tick)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-a.ctx.Done():
+			return
+		case <-ticker.C:
+			a.market.Step()
+		}
+	}
+}
+
+func (a App) runGRPC(address string) {
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		a.log.Error("failed to listen grpc", a.log.ErrorC(err))
+		return
+	}
+
+	server := grpc.NewServer()
+	spotgrpc.RegisterSpotServer(server, spotServer{app: a})
+	go func() {
+		<-a.ctx.Done()
+		server.Stop()
+	}()
+
+	a.log.Info("spot mock grpc is listening", a.log.StringC("Address", address))
+	if err := server.Serve(listener); err != nil {
+		a.log.Error("grpc server stopped", a.log.ErrorC(err))
+	}
+}
+
+func (a App) getSpot(w http.ResponseWriter, r *http.Request) {
+	usdt, spreadMin, spreadMax, err := a.getParams(r)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"Message": err.Error()})
+		return
+	}
+
+	scenario := a.script.current(time.Now())
+	a.log.Debug("spot request", a.log.StringC("Query", r.URL.RawQuery), a.log.StringC("Scenario", scenario))
+	switch scenario {
+	case ScenarioTimeout:
+		select {
+		case <-r.Context().Done():
+			return
+		case <-time.After(a.timeoutDelay):
+		}
+	case ScenarioError:
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"Message": "internal error"})
+		return
+	case ScenarioMalformed:
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(malformedJSON))
+		return
+	}
+
+	query := r.URL.Query()
+	deals := a.market.Deals(usdt, spreadMin, spreadMax, query.Get("symbol"), query.Get("market_from"),
+		query.Get("market_to"))
+	switch scenario {
+	case ScenarioEmpty:
+		deals = deals[:0]
+	case ScenarioInvalid:
+		deals = corrupt(deals)
+	}
+	writeJSON(w, http.StatusOK, transactionsToHTTP(deals))
+}
+
+func (a App) streamSpot(w http.ResponseWriter, r *http.Request) {
+	usdt, spreadMin, spreadMax, err := a.getParams(r)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"Message": err.Error()})
+		return
+	}
+	if a.script.current(time.Now()) == ScenarioError {
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"Message": "internal error"})
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeJSON(w, http.StatusNotImplemented, map[string]string{"Message": "streaming unsupported"})
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	query := r.URL.Query()
+	ticker := time.NewTicker(a.tick)
+	defer ticker.Stop()
+	for {
+		data := ""
+		switch scenario := a.script.current(time.Now()); scenario {
+		case ScenarioError:
+			return
+		case ScenarioTimeout:
+		case ScenarioMalformed:
+			data = malformedJSON
+		default:
+			deals := a.market.Deals(usdt, spreadMin, spreadMax, query.Get("symbol"), query.Get("market_from"),
+				query.Get("market_to"))
+			if scenario == ScenarioEmpty {
+				deals = deals[:0]
+			}
+			if scenario == ScenarioInvalid {
+				deals = corrupt(deals)
+			}
+			body, err := json.Marshal(transactionsToHTTP(deals))
+			if err != nil {
+				a.log.Error("failed to marshal deals", a.log.ErrorC(err))
+				return
+			}
+			data = string(body)
+		}
+
+		if data != "" {
+			if _, err := fmt.Fprintf(w, "id: %v\nevent: spot\ndata: %s\n\n", a.market.Tick(), data); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+
+		select {
+		case <-r.Context().Done():
+			return
+		case <-a.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (a App) getScenario(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]any{"Scenario": a.script.name(time.Now()),
+		"Available": append(slices.Clone(Scenarios), ScenarioScript)})
+}
+
+func (a App) setScenario(w http.ResponseWriter, r *http.Request) {
+	name := r.URL.Query().Get("name")
+	if !a.script.set(name) {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"Message": "unknown scenario " + name})
+		return
+	}
+	a.log.Info("spot mock scenario changed", a.log.StringC("Scenario", name))
+	writeJSON(w, http.StatusOK, map[string]string{"Scenario": name})
+}
+
+func (a App) getParams(r *http.Request) (float64, float64, float64, error) {
+	values := [3]float64{}
+	for i, name := range []string{"usdt", "spread_min", "spread_max"} {
+		value, err := strconv.ParseFloat(r.URL.Query().Get(name), 64)
+		if err != nil {
+			return 0, 0, 0, fmt.Errorf("invalid parameter %v", name)
+		}
+		values[i] = value
+	}
+	return values[0], values[1], values[2], nil
+}
+
+func (s spotServer) GetSpot(ctx context.Context, request *spotgrpc.GetSpotRequest) (*spotgrpc.GetSpotResponse,
+	error) {
+
+	scenario := s.app.script.current(time.Now())
+	switch scenario {
+	case ScenarioTimeout:
+		select {
+		case <-ctx.Done():
+			return nil, status.FromContextError(ctx.Err()).Err()
+		case <-time.After(s.app.timeoutDelay):
+		}
+	case ScenarioError:
+		return nil, status.Error(codes.Internal, "internal error")
+	case ScenarioMalformed:
+		return nil, status.Error(codes.DataLoss, "malformed response")
+	}
+
+	deals := s.app.market.Deals(request.GetUsdt(), request.GetSpreadMin(), request.GetSpreadMax(),
+		request.GetSymbol(), request.GetMarketFrom(), request.GetMarketTo())
+	switch scenario {
+	case ScenarioEmpty:
+		deals = deals[:0]
+	case ScenarioInvalid:
+		deals = corrupt(deals)
+	}
+	return &spotgrpc.GetSpotResponse{Transactions: transactionsToGRPC(deals)}, nil
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(value)
+}
@@ -0,0 +1,57 @@
+package spotmock
+
+import (
+	"bufio"
+	"context"
+	"crypto_pro/pkg/logger"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	spothttp "crypto_pro/internal/controller/http"
+)
+
+func TestStreamSpotFilters(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	app := App{ctx: ctx, log: logger.New(false), market: NewMarket(42), script: newScript(nil, 0),
+		tick: time.Hour}
+	first := app.market.Deals(1000, -100, 100, "", "", "")[0]
+
+	server := httptest.NewServer(http.HandlerFunc(app.streamSpot))
+	defer server.Close()
+
+	response, err := http.Get(server.URL + "?usdt=1000&spread_min=-100&spread_max=100&symbol=" + first.Symbol +
+		"&market_from=" + first.MarketFrom + "&market_to=" + first.MarketTo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer response.Body.Close()
+
+	scanner := bufio.NewScanner(response.Body)
+	scanner.Buffer(nil, 1<<20)
+	for scanner.Scan() {
+		data, ok := strings.CutPrefix(scanner.Text(), "data: ")
+		if !ok {
+			continue
+		}
+		deals := spothttp.Transactions{}
+		if err := json.Unmarshal([]byte(data), &deals); err != nil {
+			t.Fatal(err)
+		}
+		if len(deals) == 0 {
+			t.Fatal("stream returned no deals")
+		}
+		for _, deal := range deals {
+			if deal.Symbol != first.Symbol || deal.MarketFrom != first.MarketFrom || deal.MarketTo != first.MarketTo {
+				t.Errorf("deal %v %v → %v, want %v %v → %v", deal.Symbol, deal.MarketFrom, deal.MarketTo,
+					first.Symbol, first.MarketFrom, first.MarketTo)
+			}
+		}
+		return
+	}
+	t.Fatalf("stream ended without data: %v", scanner.Err())
+}
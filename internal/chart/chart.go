@@ -0,0 +1,206 @@
+package chart
+
+import (
+	"bytes"
+	"crypto_pro/internal/domain/entity"
+	"errors"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/spf13/viper"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+)
+
+const (
+	defaultHours = 6
+	maxHours     = 24
+	width        = 800
+	panelHeight  = 260
+	marginLeft   = 70
+	marginRight  = 20
+	marginTop    = 45
+	marginBottom = 30
+)
+
+var (
+	background = color.RGBA{255, 255, 255, 255}
+	gridColor  = color.RGBA{225, 225, 225, 255}
+	textColor  = color.RGBA{40, 40, 40, 255}
+	spreadLine = color.RGBA{46, 125, 50, 255}
+	askLine    = color.RGBA{198, 40, 40, 255}
+	bidLine    = color.RGBA{21, 101, 192, 255}
+)
+
+var ErrNotEnoughPoints = errors.New("not enough points to draw chart")
+
+type Renderer struct {
+	hours int
+}
+
+type series struct {
+	name   string
+	color  color.RGBA
+	values []float64
+}
+
+func New(cfg viper.Viper) *Renderer {
+	hours := cfg.GetInt("chart.hours")
+	if hours <= 0 {
+		hours = defaultHours
+	}
+	return &Renderer{hours: min(hours, maxHours)}
+}
+
+func (r *Renderer) Hours() int {
+	return r.hours
+}
+
+func (r *Renderer) Since(now time.Time) time.Time {
+	return now.Add(-time.Duration(r.hours) * time.Hour)
+}
+
+func (r *Renderer) Render(title string, points []entity.SpreadPoint) ([]byte, error) {
+	if len(points) < 2 {
+		return nil, ErrNotEnoughPoints
+	}
+
+	times := make([]time.Time, len(points))
+	spread := series{name: "Spread, %", color: spreadLine}
+	ask := series{name: "Ask cost, USDT", color: askLine}
+	bid := series{name: "Bid cost, USDT", color: bidLine}
+	for i, point := range points {
+		times[i] = point.CreatedAt
+		spread.values = append(spread.values, point.Spread)
+		ask.values = append(ask.values, point.AskCost)
+		bid.values = append(bid.values, point.BidCost)
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, width, panelHeight*2))
+	draw.Draw(img, img.Bounds(), &image.Uniform{background}, image.Point{}, draw.Src)
+
+	drawText(img, marginLeft, 16, title+" (UTC)")
+	drawPanel(img, image.Rect(0, 0, width, panelHeight), times, spread)
+	drawPanel(img, image.Rect(0, panelHeight, width, panelHeight*2), times, ask, bid)
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func drawPanel(img *image.RGBA, bounds image.Rectangle, times []time.Time, lines ...series) {
+	area := image.Rect(bounds.Min.X+marginLeft, bounds.Min.Y+marginTop, bounds.Max.X-marginRight,
+		bounds.Max.Y-marginBottom)
+
+	low, high := math.Inf(1), math.Inf(-1)
+	for _, line := range lines {
+		for _, value := range line.values {
+			low, high = math.Min(low, value), math.Max(high, value)
+		}
+	}
+	if high == low {
+		low, high = low-1, high+1
+	}
+	padding := (high - low) * 0.05
+	low, high = low-padding, high+padding
+
+	start, end := times[0], times[len(times)-1]
+	if !end.After(start) {
+		end = start.Add(time.Minute)
+	}
+
+	x := func(t time.Time) int {
+		return area.Min.X + int(float64(area.Dx())*float64(t.Sub(start))/float64(end.Sub(start)))
+	}
+	y := func(value float64) int {
+		return area.Max.Y - int(float64(area.Dy())*(value-low)/(high-low))
+	}
+
+	for i := 0; i <= 4; i++ {
+		value := low + (high-low)*float64(i)/4
+		drawLine(img, area.Min.X, y(value), area.Max.X, y(value), gridColor, 1)
+		drawText(img, bounds.Min.X+4, y(value)+4, strconv.FormatFloat(value, 'f', precision(high-low), 64))
+	}
+	for i := 0; i <= 4; i++ {
+		t := start.Add(end.Sub(start) * time.Duration(i) / 4)
+		drawLine(img, x(t), area.Min.Y, x(t), area.Max.Y, gridColor, 1)
+		drawText(img, x(t)-16, area.Max.Y+16, t.UTC().Format("15:04"))
+	}
+
+	legendX := area.Min.X
+	for _, line := range lines {
+		for i := 1; i < len(line.values); i++ {
+			drawLine(img, x(times[i-1]), y(line.values[i-1]), x(times[i]), y(line.values[i]), line.color, 2)
+		}
+		drawLine(img, legendX, area.Min.Y-8, legendX+16, area.Min.Y-8, line.color, 2)
+		drawText(img, legendX+20, area.Min.Y-4, line.name)
+		legendX += 30 + len([]rune(line.name))*7
+	}
+}
+
+func precision(span float64) int {
+	switch {
+	case span >= 100:
+		return 0
+	case span >= 1:
+		return 2
+	default:
+		return 4
+	}
+}
+
+func drawLine(img *image.RGBA, x0, y0, x1, y1 int, c color.RGBA, thickness int) {
+	dx, dy := abs(x1-x0), -abs(y1-y0)
+	sx, sy := sign(x1-x0), sign(y1-y0)
+	e := dx + dy
+	for {
+		for i := 0; i < thickness; i++ {
+			for j := 0; j < thickness; j++ {
+				img.SetRGBA(x0+i, y0+j, c)
+			}
+		}
+		if x0 == x1 && y0 == y1 {
+			return
+		}
+		e2 := 2 * e
+		if e2 >= dy {
+			e += dy
+			x0 += sx
+		}
+		if e2 <= dx {
+			e += dx
+			y0 += sy
+		}
+	}
+}
+
+func drawText(img *image.RGBA, x, y int, text string) {
+	drawer := font.Drawer{Dst: img, Src: image.NewUniform(textColor), Face: basicfont.Face7x13,
+		Dot: fixed.P(x, y)}
+	drawer.DrawString(text)
+}
+
+func abs(value int) int {
+	if value < 0 {
+		return -value
+	}
+	return value
+}
+
+func sign(value int) int {
+	switch {
+	case value > 0:
+		return 1
+	case value < 0:
+		return -1
+	}
+	return 0
+}
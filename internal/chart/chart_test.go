@@ -0,0 +1,82 @@
+package chart
+
+import (
+	"bytes"
+	"crypto_pro/internal/domain/entity"
+	"errors"
+	"image/png"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+func TestNewHours(t *testing.T) {
+	tests := []struct {
+		hours int
+		want  int
+	}{
+		{hours: 0, want: defaultHours},
+		{hours: -3, want: defaultHours},
+		{hours: 12, want: 12},
+		{hours: 48, want: maxHours},
+	}
+
+	for _, test := range tests {
+		cfg := viper.New()
+		cfg.Set("chart.hours", test.hours)
+		renderer := New(*cfg)
+		if renderer.Hours() != test.want {
+			t.Errorf("New(hours=%v).Hours() = %v, want %v", test.hours, renderer.Hours(), test.want)
+		}
+
+		now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+		if since := renderer.Since(now); now.Sub(since) != time.Duration(test.want)*time.Hour {
+			t.Errorf("New(hours=%v).Since(%v) = %v", test.hours, now, since)
+		}
+	}
+}
+
+func TestRender(t *testing.T) {
+	renderer := New(*viper.New())
+	start := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+	if _, err := renderer.Render("BTC", nil); !errors.Is(err, ErrNotEnoughPoints) {
+		t.Errorf("Render(no points) error = %v, want %v", err, ErrNotEnoughPoints)
+	}
+	if _, err := renderer.Render("BTC", []entity.SpreadPoint{{Spread: 1, CreatedAt: start}}); !errors.Is(err,
+		ErrNotEnoughPoints) {
+		t.Errorf("Render(one point) error = %v, want %v", err, ErrNotEnoughPoints)
+	}
+
+	tests := []struct {
+		name   string
+		points []entity.SpreadPoint
+	}{
+		{name: "series", points: []entity.SpreadPoint{
+			{Spread: 1.2, AskCost: 1000, BidCost: 1012, CreatedAt: start},
+			{Spread: 1.5, AskCost: 998, BidCost: 1013, CreatedAt: start.Add(2 * time.Minute)},
+			{Spread: 0.9, AskCost: 1001, BidCost: 1010, CreatedAt: start.Add(4 * time.Minute)},
+		}},
+		{name: "flat values at one moment", points: []entity.SpreadPoint{
+			{Spread: 1, AskCost: 1000, BidCost: 1010, CreatedAt: start},
+			{Spread: 1, AskCost: 1000, BidCost: 1010, CreatedAt: start},
+		}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			image, err := renderer.Render("BTC (TRC20) BYBIT -> MEXC", test.points)
+			if err != nil {
+				t.Fatal(err)
+			}
+			decoded, err := png.Decode(bytes.NewReader(image))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if bounds := decoded.Bounds(); bounds.Dx() != width || bounds.Dy() != panelHeight*2 {
+				t.Errorf("image size = %v, want %vx%v", bounds.Size(), width, panelHeight*2)
+			}
+		})
+	}
+}
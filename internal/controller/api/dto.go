@@ -0,0 +1,122 @@
+package api
+
+import (
+	"crypto_pro/internal/domain/entity"
+)
+
+func transactionsFromEntity(transactions []entity.Transaction) Transactions {
+	response := Transactions{}
+	for _, val := range transactions {
+		response = append(response, Transaction{
+			Symbol:         val.Symbol,
+			Chain:          val.Chain,
+			MarketFrom:     val.MarketFrom,
+			MarketTo:       val.MarketTo,
+			Spread:         val.Spread,
+			WithdrawFee:    val.WithDrawFee,
+			WithdrawMax:    val.WithdrawMax,
+			AmountCoin:     val.AmountCoin,
+			AmountAskOrder: val.AmountAskOrder,
+			AskCost:        val.AskCost,
+			AskOrder:       ordersFromEntity(val.AskOrder),
+			AmountBidOrder: val.AmountBidOrder,
+			BidCost:        val.BidCost,
+			BidOrder:       ordersFromEntity(val.BidOrder),
+		})
+	}
+	return response
+}
+
+func dealsFromEntity(transactions []entity.Transaction) Deals {
+	response := Deals{}
+	for _, val := range transactions {
+		response = append(response, Deal{
+			Symbol:         val.Symbol,
+			Chain:          val.Chain,
+			MarketFrom:     val.MarketFrom,
+			MarketTo:       val.MarketTo,
+			Spread:         val.Spread,
+			WithdrawFee:    val.WithDrawFee,
+			WithdrawMax:    val.WithdrawMax,
+			AmountCoin:     val.AmountCoin,
+			AmountAskOrder: val.AmountAskOrder,
+			AskCost:        val.AskCost,
+			AskOrder:       ordersFromEntity(val.AskOrder),
+			AmountBidOrder: val.AmountBidOrder,
+			BidCost:        val.BidCost,
+			BidOrder:       ordersFromEntity(val.BidOrder),
+			IsPosted:       val.IsPosted,
+			PeakSpread:     val.PeakSpread,
+			CreatedAt:      val.CreatedAt,
+			UpdatedAt:      val.UpdatedAt,
+		})
+	}
+	return response
+}
+
+func ordersFromEntity(orders []entity.Order) []Order {
+	response := []Order{}
+	for _, val := range orders {
+		response = append(response, Order{
+			Price: val.Price,
+			Qty:   val.Qty,
+		})
+	}
+	return response
+}
+
+func sessionsFromEntity(sessions []entity.Session) Sessions {
+	response := Sessions{}
+	for _, val := range sessions {
+		response = append(response, sessionFromEntity(val))
+	}
+	return response
+}
+
+func sessionFromEntity(session entity.Session) Session {
+	return Session{
+		ID:        session.ID,
+		Name:      session.Name(),
+		Usdt:      session.USDT,
+		SpreadMin: session.SpreadMin,
+		SpreadMax: session.SpreadMax,
+		Filters:   filtersFromEntity(session.Filters()),
+	}
+}
+
+func filtersFromEntity(filters entity.SessionFilters) Filters {
+	return Filters{
+		BuyMarkets:    nonNil(filters.BuyMarkets),
+		SellMarkets:   nonNil(filters.SellMarkets),
+		IncludeChains: nonNil(filters.IncludeChains),
+		ExcludeChains: nonNil(filters.ExcludeChains),
+		Guards: Guards{
+			MaxFeePercent:    filters.Guards.MaxFeePercent,
+			CheckWithdrawMax: filters.Guards.CheckWithdrawMax,
+			MinOrders:        filters.Guards.MinOrders,
+			MinDepthUsdt:     filters.Guards.MinDepthUSDT,
+		},
+	}
+}
+
+func filtersToEntity(filters Filters) entity.SessionFilters {
+	return entity.SessionFilters{
+		BuyMarkets:    filters.BuyMarkets,
+		SellMarkets:   filters.SellMarkets,
+		IncludeChains: filters.IncludeChains,
+		ExcludeChains: filters.ExcludeChains,
+		Guards: entity.Guards{
+			MaxFeePercent:    filters.Guards.MaxFeePercent,
+			CheckWithdrawMax: filters.Guards.CheckWithdrawMax,
+			MinOrders:        filters.Guards.MinOrders,
+			MinDepthUSDT:     filters.Guards.MinDepthUsdt,
+		},
+	}
+}
+
+func nonNil(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
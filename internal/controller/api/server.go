@@ -0,0 +1,178 @@
+package api
+
+import (
+	"context"
+	"crypto/subtle"
+	"crypto_pro/internal/controller"
+	"crypto_pro/internal/domain/usecase"
+	"crypto_pro/pkg/logger"
+	"errors"
+	"net"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+	"github.com/spf13/viper"
+)
+
+const shutdownTimeout = 10 * time.Second
+
+var _ ServerInterface = (*API)(nil)
+var _ controller.APIController = (*API)(nil)
+
+type API struct {
+	cfg         viper.Viper
+	log         logger.Logger
+	taskUseCase usecase.TaskUseCase
+}
+
+func New(cfg viper.Viper, log logger.Logger, taskUseCase usecase.TaskUseCase) API {
+	return API{cfg: cfg, log: log, taskUseCase: taskUseCase}
+}
+
+func (a API) Run(ctx context.Context) {
+	address := a.cfg.GetString("api.address")
+	if address == "" {
+		return
+	}
+
+	token := os.Getenv("API_TOKEN")
+	if token == "" {
+		address = loopbackAddress(address)
+		a.log.Error("api token is not set, api server is bound to loopback only",
+			a.log.StringC("Address", address))
+	}
+
+	e := a.newEcho(token)
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		if err := e.Shutdown(shutdownCtx); err != nil {
+			a.log.Error("failed to shutdown api server", a.log.ErrorC(err))
+		}
+	}()
+
+	a.log.Info("api server is listening", a.log.StringC("Address", address))
+	if err := e.Start(address); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		a.log.Error("api server stopped", a.log.ErrorC(err))
+	}
+}
+
+func (a API) newEcho(token string) *echo.Echo {
+	e := echo.New()
+	e.HideBanner, e.HidePort = true, true
+	e.Use(middleware.Recover())
+	e.Use(middleware.KeyAuthWithConfig(middleware.KeyAuthConfig{
+		Skipper: func(ctx echo.Context) bool {
+			return token == ""
+		},
+		Validator: func(key string, ctx echo.Context) (bool, error) {
+			return subtle.ConstantTimeCompare([]byte(key), []byte(token)) == 1, nil
+		},
+	}))
+	RegisterHandlers(e, a)
+	return e
+}
+
+func (a API) GetSpot(ctx echo.Context, params GetSpotParams) error {
+	transactions, err := a.taskUseCase.GetSpot(params.Usdt, params.SpreadMin, params.SpreadMax)
+	if err != nil {
+		return a.sendError(ctx, err)
+	}
+
+	response := Transactions{}
+	for _, transaction := range transactionsFromEntity(transactions) {
+		if params.Symbol != nil && transaction.Symbol != *params.Symbol {
+			continue
+		}
+		if params.MarketFrom != nil && transaction.MarketFrom != *params.MarketFrom {
+			continue
+		}
+		if params.MarketTo != nil && transaction.MarketTo != *params.MarketTo {
+			continue
+		}
+		response = append(response, transaction)
+	}
+	return ctx.JSON(http.StatusOK, response)
+}
+
+func (a API) ListSessions(ctx echo.Context, params ListSessionsParams) error {
+	prefix := ""
+	if params.Prefix != nil {
+		prefix = *params.Prefix
+	}
+	return ctx.JSON(http.StatusOK, sessionsFromEntity(a.taskUseCase.GetSessions(prefix)))
+}
+
+func (a API) GetSession(ctx echo.Context, id SessionID) error {
+	session, err := a.taskUseCase.GetSession(id)
+	if err != nil {
+		return a.sendError(ctx, err)
+	}
+	return ctx.JSON(http.StatusOK, sessionFromEntity(session))
+}
+
+func (a API) ListDeals(ctx echo.Context, id SessionID) error {
+	if _, err := a.taskUseCase.GetSession(id); err != nil {
+		return a.sendError(ctx, err)
+	}
+	return ctx.JSON(http.StatusOK, dealsFromEntity(a.taskUseCase.GetAllTransactions(id)))
+}
+
+func (a API) GetDealCard(ctx echo.Context, id SessionID, params GetDealCardParams) error {
+	text, err := a.taskUseCase.GetCard(id, params.MarketFrom, params.MarketTo, params.Symbol)
+	if err != nil {
+		return a.sendError(ctx, err)
+	}
+	return ctx.JSON(http.StatusOK, Card{Text: text, ParseMode: a.taskUseCase.GetCardParseMode()})
+}
+
+func (a API) GetFilters(ctx echo.Context, id SessionID) error {
+	session, err := a.taskUseCase.GetSession(id)
+	if err != nil {
+		return a.sendError(ctx, err)
+	}
+	return ctx.JSON(http.StatusOK, filtersFromEntity(session.Filters()))
+}
+
+func (a API) SetFilters(ctx echo.Context, id SessionID) error {
+	request := Filters{}
+	if err := ctx.Bind(&request); err != nil {
+		return ctx.JSON(http.StatusBadRequest, Error{Message: err.Error()})
+	}
+
+	filters, err := a.taskUseCase.SetSessionFilters(id, filtersToEntity(request))
+	if err != nil {
+		return a.sendError(ctx, err)
+	}
+	return ctx.JSON(http.StatusOK, filtersFromEntity(filters))
+}
+
+func (a API) sendError(ctx echo.Context, err error) error {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, usecase.ErrNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, usecase.ErrInvalidInput):
+		status = http.StatusBadRequest
+	case errors.As(err, new(*controller.SpotError)):
+		status = http.StatusServiceUnavailable
+	default:
+		a.log.Error("api request failed", a.log.StringC("Path", ctx.Path()), a.log.ErrorC(err))
+	}
+	return ctx.JSON(status, Error{Message: err.Error()})
+}
+
+func loopbackAddress(address string) string {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return address
+	}
+	if ip := net.ParseIP(host); host == "localhost" || (ip != nil && ip.IsLoopback()) {
+		return address
+	}
+	return net.JoinHostPort("127.0.0.1", port)
+}
@@ -0,0 +1,112 @@
+package api
+
+import (
+	"crypto_pro/internal/controller"
+	"crypto_pro/internal/domain/entity"
+	"crypto_pro/internal/domain/usecase/task"
+	"crypto_pro/pkg/logger"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+type spotServer struct {
+	controller.Server
+	calls int
+}
+
+func (s *spotServer) GetSpotHandler(usdt, spreadMin, spreadMax float64) ([]entity.Transaction, error) {
+	s.calls++
+	return []entity.Transaction{{Symbol: "BTC", MarketFrom: "BYBIT", MarketTo: "MEXC", Spread: 2}}, nil
+}
+
+func newTestAPI(server *spotServer) API {
+	log := logger.New(false)
+	return New(*viper.New(), log, task.New(log, server, nil, nil, nil,
+		task.NewSpotFetcher(server, time.Minute, 10)))
+}
+
+func TestAPISpotAuth(t *testing.T) {
+	tests := []struct {
+		name          string
+		token         string
+		authorization string
+		want          int
+	}{
+		{name: "no token configured", want: http.StatusOK},
+		{name: "missing", token: "secret", want: http.StatusBadRequest},
+		{name: "wrong", token: "secret", authorization: "Bearer wrong", want: http.StatusUnauthorized},
+		{name: "valid", token: "secret", authorization: "Bearer secret", want: http.StatusOK},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodGet, "/spot?usdt=1000&spread_min=1&spread_max=5", nil)
+			if test.authorization != "" {
+				request.Header.Set("Authorization", test.authorization)
+			}
+			recorder := httptest.NewRecorder()
+			newTestAPI(&spotServer{}).newEcho(test.token).ServeHTTP(recorder, request)
+
+			if recorder.Code != test.want {
+				t.Errorf("status = %v, want %v: %v", recorder.Code, test.want, recorder.Body)
+			}
+		})
+	}
+}
+
+func TestAPISpotParams(t *testing.T) {
+	tests := []struct {
+		query string
+		want  int
+	}{
+		{query: "usdt=1000&spread_min=1&spread_max=5", want: http.StatusOK},
+		{query: "usdt=1000000&spread_min=0&spread_max=100", want: http.StatusOK},
+		{query: "usdt=0&spread_min=1&spread_max=5", want: http.StatusBadRequest},
+		{query: "usdt=-5&spread_min=1&spread_max=5", want: http.StatusBadRequest},
+		{query: "usdt=1000001&spread_min=1&spread_max=5", want: http.StatusBadRequest},
+		{query: "usdt=NaN&spread_min=1&spread_max=5", want: http.StatusBadRequest},
+		{query: "usdt=Inf&spread_min=1&spread_max=5", want: http.StatusBadRequest},
+		{query: "usdt=1000&spread_min=5&spread_max=1", want: http.StatusBadRequest},
+		{query: "usdt=1000&spread_min=-1&spread_max=5", want: http.StatusBadRequest},
+		{query: "usdt=1000&spread_min=1&spread_max=101", want: http.StatusBadRequest},
+		{query: "usdt=abc&spread_min=1&spread_max=5", want: http.StatusBadRequest},
+	}
+
+	for _, test := range tests {
+		server := &spotServer{}
+		recorder := httptest.NewRecorder()
+		newTestAPI(server).newEcho("").ServeHTTP(recorder,
+			httptest.NewRequest(http.MethodGet, "/spot?"+test.query, nil))
+
+		if recorder.Code != test.want {
+			t.Errorf("%v: status = %v, want %v: %v", test.query, recorder.Code, test.want, recorder.Body)
+		}
+		if test.want != http.StatusOK && server.calls != 0 {
+			t.Errorf("%v: spot fetched for invalid params", test.query)
+		}
+	}
+}
+
+func TestLoopbackAddress(t *testing.T) {
+	tests := []struct {
+		address string
+		want    string
+	}{
+		{address: ":8081", want: "127.0.0.1:8081"},
+		{address: "0.0.0.0:8081", want: "127.0.0.1:8081"},
+		{address: "10.0.0.5:8081", want: "127.0.0.1:8081"},
+		{address: "127.0.0.1:9000", want: "127.0.0.1:9000"},
+		{address: "localhost:9000", want: "localhost:9000"},
+		{address: "[::1]:9000", want: "[::1]:9000"},
+	}
+
+	for _, test := range tests {
+		if got := loopbackAddress(test.address); got != test.want {
+			t.Errorf("loopbackAddress(%v) = %v, want %v", test.address, got, test.want)
+		}
+	}
+}
@@ -9,9 +9,24 @@ type MarkerController interface {
 }
 
 type Server interface {
-	GetSpotHandler(usdt, spreadMin, spreadMax float64) []entity.Transaction
+	GetSpotHandler(usdt, spreadMin, spreadMax float64) ([]entity.Transaction, error)
+	// GetSpotPair runs a full spot scan for the amount and spread range. The pair is sent as a hint
+	// that the service may ignore, so implementations filter the result on the client.
+	GetSpotPair(usdt, spreadMin, spreadMax float64, symbol, marketFrom, marketTo string) ([]entity.Transaction,
+		error)
+	SubscribeSpot(ctx context.Context, usdt, spreadMin, spreadMax float64) SpotStream
+}
+
+type SpotStream interface {
+	Updates() <-chan []entity.Transaction
+	Streaming() bool
 }
 
 type TelegramController interface {
 	Run(ctx context.Context)
+	SendMessage(chatID, text string) error
+}
+
+type APIController interface {
+	Run(ctx context.Context)
 }
@@ -0,0 +1,40 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrSpotTimeout     = errors.New("spot service timeout")
+	ErrSpotUnavailable = errors.New("spot service unavailable")
+	ErrSpotCircuitOpen = errors.New("spot service circuit open")
+	ErrSpotBadResponse = errors.New("spot service bad response")
+)
+
+type SpotError struct {
+	Kind       error
+	StatusCode int
+	Attempts   int
+	Err        error
+}
+
+func (e *SpotError) Error() string {
+	msg := fmt.Sprintf("%v (attempts: %v", e.Kind, e.Attempts)
+	if e.StatusCode != 0 {
+		msg += fmt.Sprintf(", status: %v", e.StatusCode)
+	}
+	msg += ")"
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+	return msg
+}
+
+func (e *SpotError) Is(target error) bool {
+	return e.Kind == target
+}
+
+func (e *SpotError) Unwrap() error {
+	return e.Err
+}
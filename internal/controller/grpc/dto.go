@@ -0,0 +1,39 @@
+package grpc
+
+import (
+	"crypto_pro/internal/domain/entity"
+)
+
+func transactionsToEntity(transactions []*Transaction) []entity.Transaction {
+	response := []entity.Transaction{}
+	for _, val := range transactions {
+		response = append(response, entity.Transaction{
+			Symbol:         val.GetSymbol(),
+			Chain:          val.GetChain(),
+			MarketFrom:     val.GetMarketFrom(),
+			MarketTo:       val.GetMarketTo(),
+			Spread:         val.GetSpread(),
+			WithDrawFee:    val.GetWithdrawFee(),
+			WithdrawMax:    val.GetWithdrawMax(),
+			AmountCoin:     val.GetAmountCoin(),
+			AmountAskOrder: val.GetAmountAskOrder(),
+			AskCost:        val.GetAskCost(),
+			AskOrder:       ordersToEntity(val.GetAskOrder()),
+			AmountBidOrder: val.GetAmountBidOrder(),
+			BidCost:        val.GetBidCost(),
+			BidOrder:       ordersToEntity(val.GetBidOrder()),
+		})
+	}
+	return response
+}
+
+func ordersToEntity(orders []*Order) []entity.Order {
+	response := []entity.Order{}
+	for _, val := range orders {
+		response = append(response, entity.Order{
+			Price: val.GetPrice(),
+			Qty:   val.GetQty(),
+		})
+	}
+	return response
+}
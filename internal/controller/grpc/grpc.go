@@ -0,0 +1,134 @@
+package grpc
+
+import (
+	"context"
+	"crypto_pro/internal/controller"
+	"crypto_pro/internal/controller/resilience"
+	"crypto_pro/internal/domain/entity"
+	"crypto_pro/internal/metrics"
+	"crypto_pro/pkg/logger"
+
+	"github.com/spf13/viper"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	status "google.golang.org/grpc/status"
+)
+
+const (
+	maxMessageSize    = 64 << 20
+	grpcEndpointLabel = "grpc"
+)
+
+var _ controller.Server = (*Server)(nil)
+
+type Server struct {
+	log     logger.Logger
+	client  SpotClient
+	retrier *resilience.Retrier
+}
+
+type spotStream struct {
+	updates chan []entity.Transaction
+}
+
+func New(ctx context.Context, cfg viper.Viper, log logger.Logger) Server {
+	conn, err := grpc.NewClient(cfg.GetString("endpoint.grpc"),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(maxMessageSize)))
+	if err != nil {
+		panic(err)
+	}
+	return NewWithClient(ctx, cfg, log, NewSpotClient(conn))
+}
+
+func NewWithClient(ctx context.Context, cfg viper.Viper, log logger.Logger, client SpotClient) Server {
+	return Server{log: log, client: client,
+		retrier: resilience.New(ctx, log, resilience.NewPolicy(cfg), resilience.NewBreaker(cfg))}
+}
+
+func (s Server) GetSpotHandler(usdt, spreadMin, spreadMax float64) ([]entity.Transaction, error) {
+	return s.getSpot(&GetSpotRequest{Usdt: usdt, SpreadMin: spreadMin, SpreadMax: spreadMax})
+}
+
+func (s Server) GetSpotPair(usdt, spreadMin, spreadMax float64, symbol, marketFrom,
+	marketTo string) ([]entity.Transaction, error) {
+
+	transactions, err := s.getSpot(&GetSpotRequest{Usdt: usdt, SpreadMin: spreadMin, SpreadMax: spreadMax,
+		Symbol: &symbol, MarketFrom: &marketFrom, MarketTo: &marketTo})
+	if err != nil {
+		return nil, err
+	}
+
+	pair := []entity.Transaction{}
+	for _, transaction := range transactions {
+		if transaction.Symbol == symbol && transaction.MarketFrom == marketFrom &&
+			transaction.MarketTo == marketTo {
+			pair = append(pair, transaction)
+		}
+	}
+	return pair, nil
+}
+
+func (s Server) SubscribeSpot(ctx context.Context, usdt, spreadMin, spreadMax float64) controller.SpotStream {
+	return &spotStream{updates: make(chan []entity.Transaction)}
+}
+
+func (s *spotStream) Updates() <-chan []entity.Transaction {
+	return s.updates
+}
+
+func (s *spotStream) Streaming() bool {
+	return false
+}
+
+func (s Server) getSpot(request *GetSpotRequest) ([]entity.Transaction, error) {
+	var response *GetSpotResponse
+	err := s.retrier.Do(resilience.Call{
+		Attempt: func(ctx context.Context) *controller.SpotError {
+			var err error
+			response, err = s.client.GetSpot(ctx, request)
+			if err != nil {
+				metrics.SpotRequests.WithLabelValues(grpcEndpointLabel, "error").Inc()
+				return classifyError(err)
+			}
+			metrics.SpotRequests.WithLabelValues(grpcEndpointLabel, "ok").Inc()
+			return nil
+		},
+		Retryable: isRetryable,
+		Trips:     tripsBreaker,
+	})
+	if err != nil {
+		s.log.Error("failed to get spot", s.log.ErrorC(err))
+		return nil, err
+	}
+	return transactionsToEntity(response.GetTransactions()), nil
+}
+
+func classifyError(err error) *controller.SpotError {
+	spotErr := &controller.SpotError{Kind: controller.ErrSpotUnavailable, Err: err}
+	switch status.Code(err) {
+	case codes.DeadlineExceeded:
+		spotErr.Kind = controller.ErrSpotTimeout
+	case codes.InvalidArgument, codes.Internal, codes.DataLoss, codes.Unimplemented:
+		spotErr.Kind = controller.ErrSpotBadResponse
+	}
+	return spotErr
+}
+
+func isRetryable(err *controller.SpotError) bool {
+	switch status.Code(err.Err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Aborted:
+		return true
+	}
+	return false
+}
+
+func tripsBreaker(err *controller.SpotError) bool {
+	switch status.Code(err.Err) {
+	case codes.InvalidArgument, codes.NotFound, codes.OutOfRange, codes.FailedPrecondition,
+		codes.PermissionDenied, codes.Unauthenticated:
+		return false
+	}
+	return true
+}
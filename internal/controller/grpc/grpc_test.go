@@ -0,0 +1,155 @@
+package grpc
+
+import (
+	"context"
+	"crypto_pro/internal/controller"
+	"crypto_pro/internal/domain/entity"
+	"crypto_pro/pkg/logger"
+	"errors"
+	"net"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	status "google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+)
+
+type fakeSpot struct {
+	UnimplementedSpotServer
+	mu       sync.Mutex
+	requests []*GetSpotRequest
+	errs     []error
+	response *GetSpotResponse
+}
+
+func (f *fakeSpot) GetSpot(ctx context.Context, request *GetSpotRequest) (*GetSpotResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.requests = append(f.requests, request)
+	if len(f.errs) > 0 {
+		err := f.errs[0]
+		f.errs = f.errs[1:]
+		return nil, err
+	}
+	return f.response, nil
+}
+
+func (f *fakeSpot) calls() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.requests)
+}
+
+func newTestServer(t *testing.T, spot *fakeSpot) Server {
+	t.Helper()
+
+	listener := bufconn.Listen(1 << 20)
+	server := grpc.NewServer()
+	RegisterSpotServer(server, spot)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	cfg := viper.New()
+	cfg.Set("spot.retries", 2)
+	cfg.Set("spot.backoff", time.Millisecond)
+	cfg.Set("spot.breaker_threshold", 2)
+	cfg.Set("spot.breaker_cooldown", time.Hour)
+	return NewWithClient(context.Background(), *cfg, logger.New(false), NewSpotClient(conn))
+}
+
+func TestGetSpot(t *testing.T) {
+	spot := &fakeSpot{response: &GetSpotResponse{Transactions: []*Transaction{{
+		Symbol: "BTC", Chain: "BTC", MarketFrom: "Binance", MarketTo: "Bybit", Spread: 1.5, WithdrawFee: 0.0001,
+		WithdrawMax: 10, AmountCoin: 0.02, AmountAskOrder: 2, AskCost: 1000,
+		AskOrder: []*Order{{Price: 50000, Qty: 0.01}, {Price: 50001, Qty: 0.01}}, AmountBidOrder: 1,
+		BidCost: 1015, BidOrder: []*Order{{Price: 50750, Qty: 0.02}},
+	}}}}
+	server := newTestServer(t, spot)
+
+	transactions, err := server.GetSpotPair(1000, 1, 5, "BTC", "Binance", "Bybit")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []entity.Transaction{{
+		Symbol: "BTC", Chain: "BTC", MarketFrom: "Binance", MarketTo: "Bybit", Spread: 1.5, WithDrawFee: 0.0001,
+		WithdrawMax: 10, AmountCoin: 0.02, AmountAskOrder: 2, AskCost: 1000,
+		AskOrder: []entity.Order{{Price: 50000, Qty: 0.01}, {Price: 50001, Qty: 0.01}}, AmountBidOrder: 1,
+		BidCost: 1015, BidOrder: []entity.Order{{Price: 50750, Qty: 0.02}},
+	}}
+	if !reflect.DeepEqual(transactions, want) {
+		t.Errorf("transactions = %+v, want %+v", transactions, want)
+	}
+
+	wantRequest := &GetSpotRequest{Usdt: 1000, SpreadMin: 1, SpreadMax: 5, Symbol: proto.String("BTC"),
+		MarketFrom: proto.String("Binance"), MarketTo: proto.String("Bybit")}
+	if !proto.Equal(spot.requests[0], wantRequest) {
+		t.Errorf("request = %v, want %v", spot.requests[0], wantRequest)
+	}
+}
+
+func TestGetSpotRetriesUnavailable(t *testing.T) {
+	spot := &fakeSpot{errs: []error{status.Error(codes.Unavailable, "down")},
+		response: &GetSpotResponse{Transactions: []*Transaction{{Symbol: "ETH"}}}}
+	server := newTestServer(t, spot)
+
+	transactions, err := server.GetSpotHandler(1000, 1, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(transactions) != 1 || transactions[0].Symbol != "ETH" {
+		t.Errorf("transactions = %+v", transactions)
+	}
+	if spot.calls() != 2 {
+		t.Errorf("calls = %v, want 2", spot.calls())
+	}
+}
+
+func TestGetSpotDoesNotRetryInvalidArgument(t *testing.T) {
+	spot := &fakeSpot{errs: []error{status.Error(codes.InvalidArgument, "bad usdt")}}
+	server := newTestServer(t, spot)
+
+	_, err := server.GetSpotHandler(-1, 1, 5)
+	var spotErr *controller.SpotError
+	if !errors.As(err, &spotErr) || !errors.Is(err, controller.ErrSpotBadResponse) || spotErr.Attempts != 1 {
+		t.Fatalf("err = %v", err)
+	}
+	if spot.calls() != 1 {
+		t.Errorf("calls = %v, want 1", spot.calls())
+	}
+}
+
+func TestGetSpotOpensBreaker(t *testing.T) {
+	unavailable := status.Error(codes.Unavailable, "down")
+	spot := &fakeSpot{errs: []error{unavailable, unavailable, unavailable, unavailable, unavailable, unavailable}}
+	server := newTestServer(t, spot)
+
+	for i := 0; i < 2; i++ {
+		if _, err := server.GetSpotHandler(1000, 1, 5); !errors.Is(err, controller.ErrSpotUnavailable) {
+			t.Fatalf("err = %v, want unavailable", err)
+		}
+	}
+	if _, err := server.GetSpotHandler(1000, 1, 5); !errors.Is(err, controller.ErrSpotCircuitOpen) {
+		t.Fatalf("err = %v, want circuit open", err)
+	}
+	if spot.calls() != 6 {
+		t.Errorf("calls = %v, want 6", spot.calls())
+	}
+}
@@ -0,0 +1,494 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.5
+// 	protoc        v5.29.3
+// source: spot.proto
+
+package grpc
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type GetSpotRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// maximum number of USDT
+	Usdt float64 `protobuf:"fixed64,1,opt,name=usdt,proto3" json:"usdt,omitempty"`
+	// minimum spread of deal
+	SpreadMin float64 `protobuf:"fixed64,2,opt,name=spread_min,json=spreadMin,proto3" json:"spread_min,omitempty"`
+	// maximum spread of deal
+	SpreadMax float64 `protobuf:"fixed64,3,opt,name=spread_max,json=spreadMax,proto3" json:"spread_max,omitempty"`
+	// return only deals for this coin
+	Symbol *string `protobuf:"bytes,4,opt,name=symbol,proto3,oneof" json:"symbol,omitempty"`
+	// return only deals bought on this market
+	MarketFrom *string `protobuf:"bytes,5,opt,name=market_from,json=marketFrom,proto3,oneof" json:"market_from,omitempty"`
+	// return only deals sold on this market
+	MarketTo      *string `protobuf:"bytes,6,opt,name=market_to,json=marketTo,proto3,oneof" json:"market_to,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSpotRequest) Reset() {
+	*x = GetSpotRequest{}
+	mi := &file_spot_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSpotRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSpotRequest) ProtoMessage() {}
+
+func (x *GetSpotRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_spot_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSpotRequest.ProtoReflect.Descriptor instead.
+func (*GetSpotRequest) Descriptor() ([]byte, []int) {
+	return file_spot_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GetSpotRequest) GetUsdt() float64 {
+	if x != nil {
+		return x.Usdt
+	}
+	return 0
+}
+
+func (x *GetSpotRequest) GetSpreadMin() float64 {
+	if x != nil {
+		return x.SpreadMin
+	}
+	return 0
+}
+
+func (x *GetSpotRequest) GetSpreadMax() float64 {
+	if x != nil {
+		return x.SpreadMax
+	}
+	return 0
+}
+
+func (x *GetSpotRequest) GetSymbol() string {
+	if x != nil && x.Symbol != nil {
+		return *x.Symbol
+	}
+	return ""
+}
+
+func (x *GetSpotRequest) GetMarketFrom() string {
+	if x != nil && x.MarketFrom != nil {
+		return *x.MarketFrom
+	}
+	return ""
+}
+
+func (x *GetSpotRequest) GetMarketTo() string {
+	if x != nil && x.MarketTo != nil {
+		return *x.MarketTo
+	}
+	return ""
+}
+
+type GetSpotResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Transactions  []*Transaction         `protobuf:"bytes,1,rep,name=transactions,proto3" json:"transactions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSpotResponse) Reset() {
+	*x = GetSpotResponse{}
+	mi := &file_spot_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSpotResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSpotResponse) ProtoMessage() {}
+
+func (x *GetSpotResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_spot_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSpotResponse.ProtoReflect.Descriptor instead.
+func (*GetSpotResponse) Descriptor() ([]byte, []int) {
+	return file_spot_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetSpotResponse) GetTransactions() []*Transaction {
+	if x != nil {
+		return x.Transactions
+	}
+	return nil
+}
+
+type Transaction struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// coin of the deal
+	Symbol string `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	// network used to transfer the coin
+	Chain string `protobuf:"bytes,2,opt,name=chain,proto3" json:"chain,omitempty"`
+	// market to buy the coin on
+	MarketFrom string `protobuf:"bytes,3,opt,name=market_from,json=marketFrom,proto3" json:"market_from,omitempty"`
+	// market to sell the coin on
+	MarketTo string `protobuf:"bytes,4,opt,name=market_to,json=marketTo,proto3" json:"market_to,omitempty"`
+	// spread of deal in percent
+	Spread float64 `protobuf:"fixed64,5,opt,name=spread,proto3" json:"spread,omitempty"`
+	// withdraw fee in coins
+	WithdrawFee float64 `protobuf:"fixed64,6,opt,name=withdraw_fee,json=withdrawFee,proto3" json:"withdraw_fee,omitempty"`
+	// maximum withdraw in coins
+	WithdrawMax float64 `protobuf:"fixed64,7,opt,name=withdraw_max,json=withdrawMax,proto3" json:"withdraw_max,omitempty"`
+	// amount of coins to buy and sell
+	AmountCoin float64 `protobuf:"fixed64,8,opt,name=amount_coin,json=amountCoin,proto3" json:"amount_coin,omitempty"`
+	// number of ask orders used
+	AmountAskOrder float64 `protobuf:"fixed64,9,opt,name=amount_ask_order,json=amountAskOrder,proto3" json:"amount_ask_order,omitempty"`
+	// cost of buying in USDT
+	AskCost  float64  `protobuf:"fixed64,10,opt,name=ask_cost,json=askCost,proto3" json:"ask_cost,omitempty"`
+	AskOrder []*Order `protobuf:"bytes,11,rep,name=ask_order,json=askOrder,proto3" json:"ask_order,omitempty"`
+	// number of bid orders used
+	AmountBidOrder float64 `protobuf:"fixed64,12,opt,name=amount_bid_order,json=amountBidOrder,proto3" json:"amount_bid_order,omitempty"`
+	// cost of selling in USDT
+	BidCost       float64  `protobuf:"fixed64,13,opt,name=bid_cost,json=bidCost,proto3" json:"bid_cost,omitempty"`
+	BidOrder      []*Order `protobuf:"bytes,14,rep,name=bid_order,json=bidOrder,proto3" json:"bid_order,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Transaction) Reset() {
+	*x = Transaction{}
+	mi := &file_spot_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Transaction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Transaction) ProtoMessage() {}
+
+func (x *Transaction) ProtoReflect() protoreflect.Message {
+	mi := &file_spot_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Transaction.ProtoReflect.Descriptor instead.
+func (*Transaction) Descriptor() ([]byte, []int) {
+	return file_spot_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Transaction) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *Transaction) GetChain() string {
+	if x != nil {
+		return x.Chain
+	}
+	return ""
+}
+
+func (x *Transaction) GetMarketFrom() string {
+	if x != nil {
+		return x.MarketFrom
+	}
+	return ""
+}
+
+func (x *Transaction) GetMarketTo() string {
+	if x != nil {
+		return x.MarketTo
+	}
+	return ""
+}
+
+func (x *Transaction) GetSpread() float64 {
+	if x != nil {
+		return x.Spread
+	}
+	return 0
+}
+
+func (x *Transaction) GetWithdrawFee() float64 {
+	if x != nil {
+		return x.WithdrawFee
+	}
+	return 0
+}
+
+func (x *Transaction) GetWithdrawMax() float64 {
+	if x != nil {
+		return x.WithdrawMax
+	}
+	return 0
+}
+
+func (x *Transaction) GetAmountCoin() float64 {
+	if x != nil {
+		return x.AmountCoin
+	}
+	return 0
+}
+
+func (x *Transaction) GetAmountAskOrder() float64 {
+	if x != nil {
+		return x.AmountAskOrder
+	}
+	return 0
+}
+
+func (x *Transaction) GetAskCost() float64 {
+	if x != nil {
+		return x.AskCost
+	}
+	return 0
+}
+
+func (x *Transaction) GetAskOrder() []*Order {
+	if x != nil {
+		return x.AskOrder
+	}
+	return nil
+}
+
+func (x *Transaction) GetAmountBidOrder() float64 {
+	if x != nil {
+		return x.AmountBidOrder
+	}
+	return 0
+}
+
+func (x *Transaction) GetBidCost() float64 {
+	if x != nil {
+		return x.BidCost
+	}
+	return 0
+}
+
+func (x *Transaction) GetBidOrder() []*Order {
+	if x != nil {
+		return x.BidOrder
+	}
+	return nil
+}
+
+type Order struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// price of one coin in USDT
+	Price float64 `protobuf:"fixed64,1,opt,name=price,proto3" json:"price,omitempty"`
+	// quantity of coins
+	Qty           float64 `protobuf:"fixed64,2,opt,name=qty,proto3" json:"qty,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Order) Reset() {
+	*x = Order{}
+	mi := &file_spot_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Order) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Order) ProtoMessage() {}
+
+func (x *Order) ProtoReflect() protoreflect.Message {
+	mi := &file_spot_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Order.ProtoReflect.Descriptor instead.
+func (*Order) Descriptor() ([]byte, []int) {
+	return file_spot_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Order) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *Order) GetQty() float64 {
+	if x != nil {
+		return x.Qty
+	}
+	return 0
+}
+
+var File_spot_proto protoreflect.FileDescriptor
+
+var file_spot_proto_rawDesc = string([]byte{
+	0x0a, 0x0a, 0x73, 0x70, 0x6f, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x07, 0x73, 0x70,
+	0x6f, 0x74, 0x2e, 0x76, 0x31, 0x22, 0xf0, 0x01, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x53, 0x70, 0x6f,
+	0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x73, 0x64, 0x74,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x04, 0x75, 0x73, 0x64, 0x74, 0x12, 0x1d, 0x0a, 0x0a,
+	0x73, 0x70, 0x72, 0x65, 0x61, 0x64, 0x5f, 0x6d, 0x69, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x09, 0x73, 0x70, 0x72, 0x65, 0x61, 0x64, 0x4d, 0x69, 0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x73,
+	0x70, 0x72, 0x65, 0x61, 0x64, 0x5f, 0x6d, 0x61, 0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x09, 0x73, 0x70, 0x72, 0x65, 0x61, 0x64, 0x4d, 0x61, 0x78, 0x12, 0x1b, 0x0a, 0x06, 0x73, 0x79,
+	0x6d, 0x62, 0x6f, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x06, 0x73, 0x79,
+	0x6d, 0x62, 0x6f, 0x6c, 0x88, 0x01, 0x01, 0x12, 0x24, 0x0a, 0x0b, 0x6d, 0x61, 0x72, 0x6b, 0x65,
+	0x74, 0x5f, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x48, 0x01, 0x52, 0x0a,
+	0x6d, 0x61, 0x72, 0x6b, 0x65, 0x74, 0x46, 0x72, 0x6f, 0x6d, 0x88, 0x01, 0x01, 0x12, 0x20, 0x0a,
+	0x09, 0x6d, 0x61, 0x72, 0x6b, 0x65, 0x74, 0x5f, 0x74, 0x6f, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09,
+	0x48, 0x02, 0x52, 0x08, 0x6d, 0x61, 0x72, 0x6b, 0x65, 0x74, 0x54, 0x6f, 0x88, 0x01, 0x01, 0x42,
+	0x09, 0x0a, 0x07, 0x5f, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x42, 0x0e, 0x0a, 0x0c, 0x5f, 0x6d,
+	0x61, 0x72, 0x6b, 0x65, 0x74, 0x5f, 0x66, 0x72, 0x6f, 0x6d, 0x42, 0x0c, 0x0a, 0x0a, 0x5f, 0x6d,
+	0x61, 0x72, 0x6b, 0x65, 0x74, 0x5f, 0x74, 0x6f, 0x22, 0x4b, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x53,
+	0x70, 0x6f, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x38, 0x0a, 0x0c, 0x74,
+	0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x14, 0x2e, 0x73, 0x70, 0x6f, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x72, 0x61, 0x6e,
+	0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0c, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0xdc, 0x03, 0x0a, 0x0b, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x12, 0x14, 0x0a,
+	0x05, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x63, 0x68,
+	0x61, 0x69, 0x6e, 0x12, 0x1f, 0x0a, 0x0b, 0x6d, 0x61, 0x72, 0x6b, 0x65, 0x74, 0x5f, 0x66, 0x72,
+	0x6f, 0x6d, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6d, 0x61, 0x72, 0x6b, 0x65, 0x74,
+	0x46, 0x72, 0x6f, 0x6d, 0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x61, 0x72, 0x6b, 0x65, 0x74, 0x5f, 0x74,
+	0x6f, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6d, 0x61, 0x72, 0x6b, 0x65, 0x74, 0x54,
+	0x6f, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x70, 0x72, 0x65, 0x61, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x06, 0x73, 0x70, 0x72, 0x65, 0x61, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x77, 0x69, 0x74,
+	0x68, 0x64, 0x72, 0x61, 0x77, 0x5f, 0x66, 0x65, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x0b, 0x77, 0x69, 0x74, 0x68, 0x64, 0x72, 0x61, 0x77, 0x46, 0x65, 0x65, 0x12, 0x21, 0x0a, 0x0c,
+	0x77, 0x69, 0x74, 0x68, 0x64, 0x72, 0x61, 0x77, 0x5f, 0x6d, 0x61, 0x78, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x0b, 0x77, 0x69, 0x74, 0x68, 0x64, 0x72, 0x61, 0x77, 0x4d, 0x61, 0x78, 0x12,
+	0x1f, 0x0a, 0x0b, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x63, 0x6f, 0x69, 0x6e, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x0a, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x43, 0x6f, 0x69, 0x6e,
+	0x12, 0x28, 0x0a, 0x10, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x61, 0x73, 0x6b, 0x5f, 0x6f,
+	0x72, 0x64, 0x65, 0x72, 0x18, 0x09, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0e, 0x61, 0x6d, 0x6f, 0x75,
+	0x6e, 0x74, 0x41, 0x73, 0x6b, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x12, 0x19, 0x0a, 0x08, 0x61, 0x73,
+	0x6b, 0x5f, 0x63, 0x6f, 0x73, 0x74, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x01, 0x52, 0x07, 0x61, 0x73,
+	0x6b, 0x43, 0x6f, 0x73, 0x74, 0x12, 0x2b, 0x0a, 0x09, 0x61, 0x73, 0x6b, 0x5f, 0x6f, 0x72, 0x64,
+	0x65, 0x72, 0x18, 0x0b, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x73, 0x70, 0x6f, 0x74, 0x2e,
+	0x76, 0x31, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x08, 0x61, 0x73, 0x6b, 0x4f, 0x72, 0x64,
+	0x65, 0x72, 0x12, 0x28, 0x0a, 0x10, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x62, 0x69, 0x64,
+	0x5f, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0e, 0x61, 0x6d,
+	0x6f, 0x75, 0x6e, 0x74, 0x42, 0x69, 0x64, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x12, 0x19, 0x0a, 0x08,
+	0x62, 0x69, 0x64, 0x5f, 0x63, 0x6f, 0x73, 0x74, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x01, 0x52, 0x07,
+	0x62, 0x69, 0x64, 0x43, 0x6f, 0x73, 0x74, 0x12, 0x2b, 0x0a, 0x09, 0x62, 0x69, 0x64, 0x5f, 0x6f,
+	0x72, 0x64, 0x65, 0x72, 0x18, 0x0e, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x73, 0x70, 0x6f,
+	0x74, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x08, 0x62, 0x69, 0x64, 0x4f,
+	0x72, 0x64, 0x65, 0x72, 0x22, 0x2f, 0x0a, 0x05, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x12, 0x14, 0x0a,
+	0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x70, 0x72,
+	0x69, 0x63, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x71, 0x74, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x03, 0x71, 0x74, 0x79, 0x32, 0x44, 0x0a, 0x04, 0x53, 0x70, 0x6f, 0x74, 0x12, 0x3c, 0x0a,
+	0x07, 0x47, 0x65, 0x74, 0x53, 0x70, 0x6f, 0x74, 0x12, 0x17, 0x2e, 0x73, 0x70, 0x6f, 0x74, 0x2e,
+	0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x70, 0x6f, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x18, 0x2e, 0x73, 0x70, 0x6f, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x53,
+	0x70, 0x6f, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x2a, 0x5a, 0x28, 0x63,
+	0x72, 0x79, 0x70, 0x74, 0x6f, 0x5f, 0x70, 0x72, 0x6f, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e,
+	0x61, 0x6c, 0x2f, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x2f, 0x67, 0x72,
+	0x70, 0x63, 0x3b, 0x67, 0x72, 0x70, 0x63, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+})
+
+var (
+	file_spot_proto_rawDescOnce sync.Once
+	file_spot_proto_rawDescData []byte
+)
+
+func file_spot_proto_rawDescGZIP() []byte {
+	file_spot_proto_rawDescOnce.Do(func() {
+		file_spot_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_spot_proto_rawDesc), len(file_spot_proto_rawDesc)))
+	})
+	return file_spot_proto_rawDescData
+}
+
+var file_spot_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_spot_proto_goTypes = []any{
+	(*GetSpotRequest)(nil),  // 0: spot.v1.GetSpotRequest
+	(*GetSpotResponse)(nil), // 1: spot.v1.GetSpotResponse
+	(*Transaction)(nil),     // 2: spot.v1.Transaction
+	(*Order)(nil),           // 3: spot.v1.Order
+}
+var file_spot_proto_depIdxs = []int32{
+	2, // 0: spot.v1.GetSpotResponse.transactions:type_name -> spot.v1.Transaction
+	3, // 1: spot.v1.Transaction.ask_order:type_name -> spot.v1.Order
+	3, // 2: spot.v1.Transaction.bid_order:type_name -> spot.v1.Order
+	0, // 3: spot.v1.Spot.GetSpot:input_type -> spot.v1.GetSpotRequest
+	1, // 4: spot.v1.Spot.GetSpot:output_type -> spot.v1.GetSpotResponse
+	4, // [4:5] is the sub-list for method output_type
+	3, // [3:4] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_spot_proto_init() }
+func file_spot_proto_init() {
+	if File_spot_proto != nil {
+		return
+	}
+	file_spot_proto_msgTypes[0].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_spot_proto_rawDesc), len(file_spot_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_spot_proto_goTypes,
+		DependencyIndexes: file_spot_proto_depIdxs,
+		MessageInfos:      file_spot_proto_msgTypes,
+	}.Build()
+	File_spot_proto = out.File
+	file_spot_proto_goTypes = nil
+	file_spot_proto_depIdxs = nil
+}
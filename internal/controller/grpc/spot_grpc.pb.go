@@ -0,0 +1,127 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.3
+// source: spot.proto
+
+package grpc
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Spot_GetSpot_FullMethodName = "/spot.v1.Spot/GetSpot"
+)
+
+// SpotClient is the client API for Spot service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Spot returns the best deals found by the scanner.
+type SpotClient interface {
+	// GetSpot returns deals for the given amount and spread range.
+	GetSpot(ctx context.Context, in *GetSpotRequest, opts ...grpc.CallOption) (*GetSpotResponse, error)
+}
+
+type spotClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSpotClient(cc grpc.ClientConnInterface) SpotClient {
+	return &spotClient{cc}
+}
+
+func (c *spotClient) GetSpot(ctx context.Context, in *GetSpotRequest, opts ...grpc.CallOption) (*GetSpotResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetSpotResponse)
+	err := c.cc.Invoke(ctx, Spot_GetSpot_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SpotServer is the server API for Spot service.
+// All implementations must embed UnimplementedSpotServer
+// for forward compatibility.
+//
+// Spot returns the best deals found by the scanner.
+type SpotServer interface {
+	// GetSpot returns deals for the given amount and spread range.
+	GetSpot(context.Context, *GetSpotRequest) (*GetSpotResponse, error)
+	mustEmbedUnimplementedSpotServer()
+}
+
+// UnimplementedSpotServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedSpotServer struct{}
+
+func (UnimplementedSpotServer) GetSpot(context.Context, *GetSpotRequest) (*GetSpotResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSpot not implemented")
+}
+func (UnimplementedSpotServer) mustEmbedUnimplementedSpotServer() {}
+func (UnimplementedSpotServer) testEmbeddedByValue()              {}
+
+// UnsafeSpotServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SpotServer will
+// result in compilation errors.
+type UnsafeSpotServer interface {
+	mustEmbedUnimplementedSpotServer()
+}
+
+func RegisterSpotServer(s grpc.ServiceRegistrar, srv SpotServer) {
+	// If the following call pancis, it indicates UnimplementedSpotServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Spot_ServiceDesc, srv)
+}
+
+func _Spot_GetSpot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSpotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SpotServer).GetSpot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Spot_GetSpot_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SpotServer).GetSpot(ctx, req.(*GetSpotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Spot_ServiceDesc is the grpc.ServiceDesc for Spot service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Spot_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "spot.v1.Spot",
+	HandlerType: (*SpotServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetSpot",
+			Handler:    _Spot_GetSpot_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "spot.proto",
+}
@@ -4,191 +4,397 @@
 package http
 
 import (
-	"bytes"
-	"compress/gzip"
-	"encoding/base64"
+	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
-	"path"
 	"strings"
 
-	"github.com/getkin/kin-openapi/openapi3"
-	"github.com/labstack/echo/v4"
 	"github.com/oapi-codegen/runtime"
 )
 
+// Order defines model for Order.
+type Order struct {
+	// Price price of one coin in USDT
+	Price float64 `json:"Price"`
+
+	// Qty quantity of coins
+	Qty float64 `json:"Qty"`
+}
+
+// Transaction defines model for Transaction.
+type Transaction struct {
+	// AmountAskOrder number of ask orders used
+	AmountAskOrder float64 `json:"AmountAskOrder"`
+
+	// AmountBidOrder number of bid orders used
+	AmountBidOrder float64 `json:"AmountBidOrder"`
+
+	// AmountCoin amount of coins to buy and sell
+	AmountCoin float64 `json:"AmountCoin"`
+
+	// AskCost cost of buying in USDT
+	AskCost  float64 `json:"AskCost"`
+	AskOrder []Order `json:"AskOrder"`
+
+	// BidCost cost of selling in USDT
+	BidCost  float64 `json:"BidCost"`
+	BidOrder []Order `json:"BidOrder"`
+
+	// Chain network used to transfer the coin
+	Chain string `json:"Chain"`
+
+	// MarketFrom market to buy the coin on
+	MarketFrom string `json:"MarketFrom"`
+
+	// MarketTo market to sell the coin on
+	MarketTo string `json:"MarketTo"`
+
+	// Spread spread of deal in percent
+	Spread float64 `json:"Spread"`
+
+	// Symbol coin of the deal
+	Symbol string `json:"Symbol"`
+
+	// WithdrawFee withdraw fee in coins
+	WithdrawFee float64 `json:"WithdrawFee"`
+
+	// WithdrawMax maximum withdraw in coins
+	WithdrawMax float64 `json:"WithdrawMax"`
+}
+
+// Transactions defines model for Transactions.
+type Transactions = []Transaction
+
 // GetSpotParams defines parameters for GetSpot.
 type GetSpotParams struct {
 	// Usdt maximum number of USDT
-	Usdt float32 `form:"usdt" json:"usdt"`
+	Usdt float64 `form:"usdt" json:"usdt"`
 
 	// SpreadMin minimum spread of deal
-	SpreadMin float32 `form:"spread_min" json:"spread_min"`
+	SpreadMin float64 `form:"spread_min" json:"spread_min"`
 
 	// SpreadMax maximum spread of deal
-	SpreadMax float32 `form:"spread_max" json:"spread_max"`
+	SpreadMax float64 `form:"spread_max" json:"spread_max"`
+
+	// Symbol return only deals for this coin
+	Symbol *string `form:"symbol,omitempty" json:"symbol,omitempty"`
+
+	// MarketFrom return only deals bought on this market
+	MarketFrom *string `form:"market_from,omitempty" json:"market_from,omitempty"`
+
+	// MarketTo return only deals sold on this market
+	MarketTo *string `form:"market_to,omitempty" json:"market_to,omitempty"`
 }
 
-// ServerInterface represents all server handlers.
-type ServerInterface interface {
-	// transaction
-	// (GET /spot)
-	GetSpot(ctx echo.Context, params GetSpotParams) error
+// RequestEditorFn  is the function signature for the RequestEditor callback function
+type RequestEditorFn func(ctx context.Context, req *http.Request) error
+
+// Doer performs HTTP requests.
+//
+// The standard http.Client implements this interface.
+type HttpRequestDoer interface {
+	Do(req *http.Request) (*http.Response, error)
 }
 
-// ServerInterfaceWrapper converts echo contexts to parameters.
-type ServerInterfaceWrapper struct {
-	Handler ServerInterface
+// Client which conforms to the OpenAPI3 specification for this service.
+type Client struct {
+	// The endpoint of the server conforming to this interface, with scheme,
+	// https://api.deepmap.com for example. This can contain a path relative
+	// to the server, such as https://api.deepmap.com/dev-test, and all the
+	// paths in the swagger spec will be appended to the server.
+	Server string
+
+	// Doer for performing requests, typically a *http.Client with any
+	// customized settings, such as certificate chains.
+	Client HttpRequestDoer
+
+	// A list of callbacks for modifying requests which are generated before sending over
+	// the network.
+	RequestEditors []RequestEditorFn
 }
 
-// GetSpot converts echo context to params.
-func (w *ServerInterfaceWrapper) GetSpot(ctx echo.Context) error {
-	var err error
+// ClientOption allows setting custom parameters during construction
+type ClientOption func(*Client) error
+
+// Creates a new Client, with reasonable defaults
+func NewClient(server string, opts ...ClientOption) (*Client, error) {
+	// create a client with sane default values
+	client := Client{
+		Server: server,
+	}
+	// mutate client and add all optional params
+	for _, o := range opts {
+		if err := o(&client); err != nil {
+			return nil, err
+		}
+	}
+	// ensure the server URL always has a trailing slash
+	if !strings.HasSuffix(client.Server, "/") {
+		client.Server += "/"
+	}
+	// create httpClient, if not already present
+	if client.Client == nil {
+		client.Client = &http.Client{}
+	}
+	return &client, nil
+}
+
+// WithHTTPClient allows overriding the default Doer, which is
+// automatically created using http.Client. This is useful for tests.
+func WithHTTPClient(doer HttpRequestDoer) ClientOption {
+	return func(c *Client) error {
+		c.Client = doer
+		return nil
+	}
+}
+
+// WithRequestEditorFn allows setting up a callback function, which will be
+// called right before sending the request. This can be used to mutate the request.
+func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
+	return func(c *Client) error {
+		c.RequestEditors = append(c.RequestEditors, fn)
+		return nil
+	}
+}
 
-	// Parameter object where we will unmarshal all parameters from the context
-	var params GetSpotParams
-	// ------------- Required query parameter "usdt" -------------
+// The interface specification for the client above.
+type ClientInterface interface {
+	// GetSpot request
+	GetSpot(ctx context.Context, params *GetSpotParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+}
 
-	err = runtime.BindQueryParameter("form", true, true, "usdt", ctx.QueryParams(), &params.Usdt)
+func (c *Client) GetSpot(ctx context.Context, params *GetSpotParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetSpotRequest(c.Server, params)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter usdt: %s", err))
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	// ------------- Required query parameter "spread_min" -------------
+// NewGetSpotRequest generates requests for GetSpot
+func NewGetSpotRequest(server string, params *GetSpotParams) (*http.Request, error) {
+	var err error
 
-	err = runtime.BindQueryParameter("form", true, true, "spread_min", ctx.QueryParams(), &params.SpreadMin)
+	serverURL, err := url.Parse(server)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter spread_min: %s", err))
+		return nil, err
 	}
 
-	// ------------- Required query parameter "spread_max" -------------
+	operationPath := fmt.Sprintf("/spot")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
 
-	err = runtime.BindQueryParameter("form", true, true, "spread_max", ctx.QueryParams(), &params.SpreadMax)
+	queryURL, err := serverURL.Parse(operationPath)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter spread_max: %s", err))
+		return nil, err
 	}
 
-	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.GetSpot(ctx, params)
-	return err
-}
+	if params != nil {
+		queryValues := queryURL.Query()
 
-// This is a simple interface which specifies echo.Route addition functions which
-// are present on both echo.Echo and echo.Group, since we want to allow using
-// either of them for path registration
-type EchoRouter interface {
-	CONNECT(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
-	DELETE(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
-	GET(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
-	HEAD(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
-	OPTIONS(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
-	PATCH(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
-	POST(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
-	PUT(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
-	TRACE(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
-}
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "usdt", runtime.ParamLocationQuery, params.Usdt); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
 
-// RegisterHandlers adds each server route to the EchoRouter.
-func RegisterHandlers(router EchoRouter, si ServerInterface) {
-	RegisterHandlersWithBaseURL(router, si, "")
-}
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "spread_min", runtime.ParamLocationQuery, params.SpreadMin); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
 
-// Registers handlers, and prepends BaseURL to the paths, so that the paths
-// can be served under a prefix.
-func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL string) {
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "spread_max", runtime.ParamLocationQuery, params.SpreadMax); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
 
-	wrapper := ServerInterfaceWrapper{
-		Handler: si,
-	}
+		if params.Symbol != nil {
 
-	router.GET(baseURL+"/spot", wrapper.GetSpot)
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "symbol", runtime.ParamLocationQuery, *params.Symbol); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
 
-}
+		}
 
-// Base64 encoded, gzipped, json marshaled Swagger object
-var swaggerSpec = []string{
+		if params.MarketFrom != nil {
 
-	"H4sIAAAAAAAC/8xVX0/bThD8KtY+W8nl91Olym+lRRVCBamESlWFrI29SY7k/rC3BiKU716tDW1iXAh9",
-	"6lMudzOzc7d34wewfh6geICaUsU2ig0eCliQZDUKZnMOLnPIK5Lszsoym1GSTBh9wqoF5yBW1gQFfD2+",
-	"mGaJ+JY4mwfOUoW6fEucOtXJyIwMbHMIkTxGCwX8PzKjCeQQUZZJfYxTDKKDBcmwrwEHIRKjjk9qKOAz",
-	"yYWKqCqjIyFOUPzoazm8t65xmW/cjDgL8+zy4tMUcrC6etMQbyAHj0731qRaBZluGstUQyHcUA6pWpJD",
-	"9Un36KIew8QYk4NsotI6bdhu82fVrW+rp8iEtVavCdd/qN6BSmf9gR4OMfC4/TcZwPvDDLx7buBKiSkG",
-	"n6ht9H/G6E8VvJBvW40xrm3V9nF8ndTkw462FXItMbK2W2wn8yGtzrkmfgES2Va0Z8+MnhvM4aaUTb+V",
-	"Awf5NBNm11QJ/J5AZtzo/yNb/3Oe0IXGS4lpVYYnbzuiA6UfGTNbv5FRBev3b4MZRKdVWYUkr24vB/Vw",
-	"ILRaYq86fDs+O/8Cv7BJ2PqFYrtcKzXj9hlH349Opi8wJOzjTy9Pz0/Ohgjdw3nlceaQNm4W1j0T049D",
-	"iprCZc14V86pf4OMGRJXRkvQ5/tqVO3ieyc5+Yubp5D94GG7WEr2lAWtRmqcQ95AAbu53nK770mX3w2v",
-	"oYClSCzG426heG+Mge3V9mcAAAD///fIr83KBgAA",
-}
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "market_from", runtime.ParamLocationQuery, *params.MarketFrom); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
 
-// GetSwagger returns the content of the embedded swagger specification file
-// or error if failed to decode
-func decodeSpec() ([]byte, error) {
-	zipped, err := base64.StdEncoding.DecodeString(strings.Join(swaggerSpec, ""))
-	if err != nil {
-		return nil, fmt.Errorf("error base64 decoding spec: %w", err)
-	}
-	zr, err := gzip.NewReader(bytes.NewReader(zipped))
-	if err != nil {
-		return nil, fmt.Errorf("error decompressing spec: %w", err)
+		}
+
+		if params.MarketTo != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "market_to", runtime.ParamLocationQuery, *params.MarketTo); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
 	}
-	var buf bytes.Buffer
-	_, err = buf.ReadFrom(zr)
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
 	if err != nil {
-		return nil, fmt.Errorf("error decompressing spec: %w", err)
+		return nil, err
 	}
 
-	return buf.Bytes(), nil
+	return req, nil
+}
+
+func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
+	for _, r := range c.RequestEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
-var rawSpec = decodeSpecCached()
+// ClientWithResponses builds on ClientInterface to offer response payloads
+type ClientWithResponses struct {
+	ClientInterface
+}
 
-// a naive cached of a decoded swagger spec
-func decodeSpecCached() func() ([]byte, error) {
-	data, err := decodeSpec()
-	return func() ([]byte, error) {
-		return data, err
+// NewClientWithResponses creates a new ClientWithResponses, which wraps
+// Client with return type handling
+func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
+	client, err := NewClient(server, opts...)
+	if err != nil {
+		return nil, err
 	}
+	return &ClientWithResponses{client}, nil
 }
 
-// Constructs a synthetic filesystem for resolving external references when loading openapi specifications.
-func PathToRawSpec(pathToFile string) map[string]func() ([]byte, error) {
-	res := make(map[string]func() ([]byte, error))
-	if len(pathToFile) > 0 {
-		res[pathToFile] = rawSpec
+// WithBaseURL overrides the baseURL.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) error {
+		newBaseURL, err := url.Parse(baseURL)
+		if err != nil {
+			return err
+		}
+		c.Server = newBaseURL.String()
+		return nil
 	}
+}
 
-	return res
+// ClientWithResponsesInterface is the interface specification for the client with responses above.
+type ClientWithResponsesInterface interface {
+	// GetSpotWithResponse request
+	GetSpotWithResponse(ctx context.Context, params *GetSpotParams, reqEditors ...RequestEditorFn) (*GetSpotResponse, error)
 }
 
-// GetSwagger returns the Swagger specification corresponding to the generated code
-// in this file. The external references of Swagger specification are resolved.
-// The logic of resolving external references is tightly connected to "import-mapping" feature.
-// Externally referenced files must be embedded in the corresponding golang packages.
-// Urls can be supported but this task was out of the scope.
-func GetSwagger() (swagger *openapi3.T, err error) {
-	resolvePath := PathToRawSpec("")
+type GetSpotResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Transactions
+}
 
-	loader := openapi3.NewLoader()
-	loader.IsExternalRefsAllowed = true
-	loader.ReadFromURIFunc = func(loader *openapi3.Loader, url *url.URL) ([]byte, error) {
-		pathToFile := url.String()
-		pathToFile = path.Clean(pathToFile)
-		getSpec, ok := resolvePath[pathToFile]
-		if !ok {
-			err1 := fmt.Errorf("path not found: %s", pathToFile)
-			return nil, err1
-		}
-		return getSpec()
+// Status returns HTTPResponse.Status
+func (r GetSpotResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetSpotResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
 	}
-	var specData []byte
-	specData, err = rawSpec()
+	return 0
+}
+
+// GetSpotWithResponse request returning *GetSpotResponse
+func (c *ClientWithResponses) GetSpotWithResponse(ctx context.Context, params *GetSpotParams, reqEditors ...RequestEditorFn) (*GetSpotResponse, error) {
+	rsp, err := c.GetSpot(ctx, params, reqEditors...)
 	if err != nil {
-		return
+		return nil, err
 	}
-	swagger, err = loader.LoadFromData(specData)
+	return ParseGetSpotResponse(rsp)
+}
+
+// ParseGetSpotResponse parses an HTTP response from a GetSpotWithResponse call
+func ParseGetSpotResponse(rsp *http.Response) (*GetSpotResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
-		return
+		return nil, err
+	}
+
+	response := &GetSpotResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
 	}
-	return
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Transactions
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
 }
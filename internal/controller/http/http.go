@@ -1,13 +1,12 @@
 package http
 
 import (
+	"context"
 	"crypto_pro/internal/controller"
+	"crypto_pro/internal/controller/resilience"
 	"crypto_pro/internal/domain/entity"
 	"crypto_pro/internal/domain/usecase"
 	"crypto_pro/pkg/logger"
-	"encoding/json"
-	"fmt"
-	"io"
 	"net/http"
 
 	"github.com/spf13/viper"
@@ -18,70 +17,52 @@ var _ controller.Server = (*Server)(nil)
 type Server struct {
 	cfg         viper.Viper
 	log         logger.Logger
-	client      *http.Client
-	host        string
+	pool        *endpointPool
+	retrier     *resilience.Retrier
+	stream      streamPolicy
 	taskUseCase usecase.TaskUseCase
 }
 
-func New(cfg viper.Viper, log logger.Logger, taskUseCase usecase.TaskUseCase) Server {
-	host := cfg.GetString("endpoint.spot_local")
-	server := Server{
-		client:      &http.Client{},
-		host:        host,
+func New(ctx context.Context, cfg viper.Viper, log logger.Logger, taskUseCase usecase.TaskUseCase) Server {
+	policy := resilience.NewPolicy(cfg)
+	return Server{
 		cfg:         cfg,
 		log:         log,
+		pool:        newEndpointPool(ctx, cfg, log, &http.Client{Timeout: policy.Timeout}),
+		retrier:     resilience.New(ctx, log, policy, resilience.NewBreaker(cfg)),
+		stream:      newStreamPolicy(cfg),
 		taskUseCase: taskUseCase,
 	}
-
-	if err := server.checkLocalEndpoint(); err != nil {
-		log.Info("failed to check local endpoint", log.ErrorC(err))
-		host = cfg.GetString("endpoint.spot_remote")
-	}
-
-	server = server.setHost(host)
-	return server
 }
 
-func (s Server) setHost(host string) Server {
-	s.host = host
-	return s
+func (s Server) GetSpotHandler(usdt, spreadMin, spreadMax float64) ([]entity.Transaction, error) {
+	return s.getSpot(GetSpotParams{Usdt: usdt, SpreadMin: spreadMin, SpreadMax: spreadMax})
 }
 
-func (s Server) checkLocalEndpoint() error {
-	url := s.cfg.GetString("endpoint.spot_local")
-	response, err := s.client.Get(url)
-	if err != nil {
-		s.log.Error("failed to get spot", s.log.ErrorC(err))
-		return err
-	}
-	defer response.Body.Close()
-	return nil
-}
+func (s Server) GetSpotPair(usdt, spreadMin, spreadMax float64, symbol, marketFrom,
+	marketTo string) ([]entity.Transaction, error) {
 
-func (s Server) GetSpotHandler(usdt, spreadMin, spreadMax float64) []entity.Transaction {
-	url := fmt.Sprintf("%s?usdt=%f&spread_min=%f&spread_max=%f", s.host, usdt, spreadMin, spreadMax)
-	response, err := s.client.Get(url)
+	transactions, err := s.getSpot(GetSpotParams{Usdt: usdt, SpreadMin: spreadMin,
+		SpreadMax: spreadMax, Symbol: &symbol, MarketFrom: &marketFrom, MarketTo: &marketTo})
 	if err != nil {
-		s.log.Error("failed to get spot", s.log.ErrorC(err))
-		return nil
+		return nil, err
 	}
-	defer response.Body.Close()
 
-	bodyResponse, err := io.ReadAll(response.Body)
-	if err != nil {
-		s.log.Error("failed to read body response", s.log.ErrorC(err))
-		return nil
+	pair := []entity.Transaction{}
+	for _, transaction := range transactions {
+		if transaction.Symbol == symbol && transaction.MarketFrom == marketFrom &&
+			transaction.MarketTo == marketTo {
+			pair = append(pair, transaction)
+		}
 	}
-
-	transactions := s.transactionUnmarshal(s.log, bodyResponse)
-	return transactions.toEntity()
+	return pair, nil
 }
 
-func (s Server) transactionUnmarshal(log logger.Logger, body []byte) transactions {
-	response := transactions{}
-	err := json.Unmarshal(body, &response)
+func (s Server) getSpot(params GetSpotParams) ([]entity.Transaction, error) {
+	response, err := s.fetchSpot(params)
 	if err != nil {
-		log.Error("Error when unmarshal data", log.ErrorC(err))
+		s.log.Error("failed to get spot", s.log.ErrorC(err))
+		return nil, err
 	}
-	return response
+	return transactionsToEntity(*response.JSON200), nil
 }
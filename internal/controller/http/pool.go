@@ -0,0 +1,162 @@
+package http
+
+import (
+	"context"
+	"crypto_pro/internal/controller"
+	"crypto_pro/internal/metrics"
+	"crypto_pro/pkg/logger"
+	"math/rand/v2"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+const defaultProbeInterval = 30 * time.Second
+
+var probeParams = GetSpotParams{Usdt: 1, SpreadMin: 100, SpreadMax: 100}
+
+type endpointConfig struct {
+	URL    string `mapstructure:"url"`
+	Weight int    `mapstructure:"weight"`
+}
+
+type endpoint struct {
+	url     string
+	weight  int
+	client  ClientWithResponsesInterface
+	healthy atomic.Bool
+}
+
+type endpointPool struct {
+	log       logger.Logger
+	timeout   time.Duration
+	endpoints []*endpoint
+}
+
+func newEndpointPool(ctx context.Context, cfg viper.Viper, log logger.Logger, httpClient *http.Client) *endpointPool {
+	configs := []endpointConfig{}
+	if err := cfg.UnmarshalKey("endpoint.spots", &configs); err != nil {
+		panic(err)
+	}
+	if len(configs) == 0 {
+		for _, key := range []string{"endpoint.spot_local", "endpoint.spot_remote"} {
+			if url := cfg.GetString(key); url != "" {
+				configs = append(configs, endpointConfig{URL: url, Weight: 1})
+			}
+		}
+	}
+
+	pool := &endpointPool{log: log, timeout: httpClient.Timeout}
+	for _, config := range configs {
+		client, err := NewClientWithResponses(strings.TrimSuffix(config.URL, "/spot"), WithHTTPClient(httpClient))
+		if err != nil {
+			panic(err)
+		}
+		pool.endpoints = append(pool.endpoints, &endpoint{url: config.URL, weight: max(config.Weight, 1),
+			client: client})
+	}
+	if len(pool.endpoints) == 0 {
+		panic("no spot endpoints configured")
+	}
+
+	pool.probe(ctx)
+
+	interval := cfg.GetDuration("endpoint.probe_interval")
+	if interval <= 0 {
+		interval = defaultProbeInterval
+	}
+	go pool.runProbes(ctx, interval)
+	return pool
+}
+
+func (p *endpointPool) runProbes(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			p.probe(ctx)
+		}
+	}
+}
+
+func (p *endpointPool) probe(ctx context.Context) {
+	for _, endpoint := range p.endpoints {
+		if err := p.probeOne(ctx, endpoint); err != nil {
+			p.markDown(endpoint, err)
+			continue
+		}
+		p.markUp(endpoint)
+	}
+}
+
+func (p *endpointPool) probeOne(ctx context.Context, endpoint *endpoint) error {
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	response, err := endpoint.client.GetSpotWithResponse(ctx, &probeParams)
+	if err != nil {
+		return err
+	}
+	if response.StatusCode() < http.StatusOK || response.StatusCode() >= http.StatusMultipleChoices {
+		return &controller.SpotError{Kind: controller.ErrSpotBadResponse, StatusCode: response.StatusCode()}
+	}
+	return nil
+}
+
+func (p *endpointPool) pick(tried map[*endpoint]bool) *endpoint {
+	candidates := []*endpoint{}
+	for _, endpoint := range p.endpoints {
+		if endpoint.healthy.Load() && !tried[endpoint] {
+			candidates = append(candidates, endpoint)
+		}
+	}
+	if len(candidates) == 0 {
+		for _, endpoint := range p.endpoints {
+			if !tried[endpoint] {
+				candidates = append(candidates, endpoint)
+			}
+		}
+	}
+	if len(candidates) == 0 {
+		candidates = p.endpoints
+	}
+
+	total := 0
+	for _, endpoint := range candidates {
+		total += endpoint.weight
+	}
+	n := rand.N(total)
+	for _, endpoint := range candidates {
+		if n < endpoint.weight {
+			return endpoint
+		}
+		n -= endpoint.weight
+	}
+	return candidates[len(candidates)-1]
+}
+
+func (p *endpointPool) markUp(endpoint *endpoint) {
+	metrics.SpotEndpointUp.WithLabelValues(endpoint.url).Set(1)
+	if !endpoint.healthy.Swap(true) {
+		p.log.Info("spot endpoint is up", p.log.StringC("Endpoint", endpoint.url))
+	}
+}
+
+func (p *endpointPool) markDown(endpoint *endpoint, err error) {
+	metrics.SpotEndpointUp.WithLabelValues(endpoint.url).Set(0)
+	if endpoint.healthy.Swap(false) {
+		metrics.SpotFailovers.Inc()
+		if err != nil {
+			p.log.Error("spot endpoint is down", p.log.StringC("Endpoint", endpoint.url), p.log.ErrorC(err))
+			return
+		}
+		p.log.Error("spot endpoint is down", p.log.StringC("Endpoint", endpoint.url))
+	}
+}
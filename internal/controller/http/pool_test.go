@@ -0,0 +1,72 @@
+package http
+
+import (
+	"context"
+	"crypto_pro/pkg/logger"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+func newTestPool(t *testing.T, ctx context.Context, status int) (*endpointPool, chan string) {
+	t.Helper()
+
+	queries := make(chan string, 16)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries <- r.URL.Path + "?" + r.URL.RawQuery
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		w.Write([]byte("[]"))
+	}))
+	t.Cleanup(server.Close)
+
+	cfg := viper.New()
+	cfg.Set("endpoint.spot_local", server.URL+"/spot")
+	cfg.Set("endpoint.probe_interval", 10*time.Millisecond)
+	return newEndpointPool(ctx, *cfg, logger.New(false), &http.Client{Timeout: time.Second}), queries
+}
+
+func TestProbeStatus(t *testing.T) {
+	tests := []struct {
+		status  int
+		healthy bool
+	}{
+		{status: http.StatusOK, healthy: true},
+		{status: http.StatusBadRequest},
+		{status: http.StatusNotFound},
+		{status: http.StatusServiceUnavailable},
+	}
+
+	for _, test := range tests {
+		ctx, cancel := context.WithCancel(context.Background())
+		pool, queries := newTestPool(t, ctx, test.status)
+		cancel()
+
+		if got := pool.endpoints[0].healthy.Load(); got != test.healthy {
+			t.Errorf("status %v: healthy = %v, want %v", test.status, got, test.healthy)
+		}
+		if query := <-queries; query != "/spot?spread_max=100&spread_min=100&usdt=1" {
+			t.Errorf("probe query = %v", query)
+		}
+	}
+}
+
+func TestProbesStopOnCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	_, queries := newTestPool(t, ctx, http.StatusOK)
+	<-queries
+	<-queries
+	cancel()
+
+	time.Sleep(50 * time.Millisecond)
+	for len(queries) > 0 {
+		<-queries
+	}
+	time.Sleep(50 * time.Millisecond)
+	if len(queries) > 0 {
+		t.Fatal("probes continued after ctx cancellation")
+	}
+}
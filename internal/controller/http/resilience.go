@@ -0,0 +1,80 @@
+package http
+
+import (
+	"context"
+	"crypto_pro/internal/controller"
+	"crypto_pro/internal/controller/resilience"
+	"crypto_pro/internal/metrics"
+	"errors"
+	"net"
+	"net/http"
+)
+
+func (s Server) fetchSpot(params GetSpotParams) (*GetSpotResponse, error) {
+	var response *GetSpotResponse
+	tried := map[*endpoint]bool{}
+	err := s.retrier.Do(resilience.Call{
+		Attempt: func(ctx context.Context) *controller.SpotError {
+			endpoint := s.pool.pick(tried)
+			endpointResponse, err := s.fetchSpotOnce(ctx, endpoint, params)
+			if err == nil {
+				metrics.SpotRequests.WithLabelValues(endpoint.url, "ok").Inc()
+				s.pool.markUp(endpoint)
+				response = endpointResponse
+				return nil
+			}
+
+			spotErr := s.classifyError(endpointResponse, err)
+			metrics.SpotRequests.WithLabelValues(endpoint.url, "error").Inc()
+			if s.isRetryable(spotErr) {
+				tried[endpoint] = true
+				s.pool.markDown(endpoint, spotErr)
+			}
+			return spotErr
+		},
+		Retryable: s.isRetryable,
+		Trips:     tripsBreaker,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+func (s Server) fetchSpotOnce(ctx context.Context, endpoint *endpoint, params GetSpotParams) (*GetSpotResponse,
+	error) {
+
+	response, err := endpoint.client.GetSpotWithResponse(ctx, &params)
+	if err != nil {
+		return nil, err
+	}
+	if response.StatusCode() != http.StatusOK || response.JSON200 == nil {
+		return response, controller.ErrSpotBadResponse
+	}
+	return response, nil
+}
+
+func (s Server) classifyError(response *GetSpotResponse, err error) *controller.SpotError {
+	spotErr := &controller.SpotError{Kind: controller.ErrSpotUnavailable, Err: err}
+
+	var netErr net.Error
+	switch {
+	case errors.Is(err, controller.ErrSpotBadResponse):
+		spotErr.Kind, spotErr.Err = controller.ErrSpotBadResponse, nil
+		spotErr.StatusCode = response.StatusCode()
+	case errors.Is(err, context.DeadlineExceeded), errors.As(err, &netErr) && netErr.Timeout():
+		spotErr.Kind = controller.ErrSpotTimeout
+	}
+	return spotErr
+}
+
+func (s Server) isRetryable(err *controller.SpotError) bool {
+	if errors.Is(err, controller.ErrSpotBadResponse) {
+		return err.StatusCode == http.StatusTooManyRequests || err.StatusCode >= http.StatusInternalServerError
+	}
+	return true
+}
+
+func tripsBreaker(err *controller.SpotError) bool {
+	return !errors.Is(err, controller.ErrSpotBadResponse) || err.StatusCode >= http.StatusInternalServerError
+}
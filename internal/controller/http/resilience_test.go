@@ -0,0 +1,95 @@
+package http
+
+import (
+	"context"
+	"crypto_pro/internal/controller"
+	"errors"
+	"net"
+	"net/http"
+	"testing"
+)
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+var _ net.Error = timeoutError{}
+
+func responseWithStatus(status int) *GetSpotResponse {
+	return &GetSpotResponse{HTTPResponse: &http.Response{StatusCode: status}}
+}
+
+func TestClassifyError(t *testing.T) {
+	tests := []struct {
+		name     string
+		response *GetSpotResponse
+		err      error
+		kind     error
+		status   int
+	}{
+		{name: "bad response", response: responseWithStatus(http.StatusBadGateway),
+			err: controller.ErrSpotBadResponse, kind: controller.ErrSpotBadResponse, status: http.StatusBadGateway},
+		{name: "deadline", err: context.DeadlineExceeded, kind: controller.ErrSpotTimeout},
+		{name: "net timeout", err: timeoutError{}, kind: controller.ErrSpotTimeout},
+		{name: "refused", err: errors.New("connection refused"), kind: controller.ErrSpotUnavailable},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := Server{}.classifyError(test.response, test.err)
+			if !errors.Is(got, test.kind) {
+				t.Errorf("kind = %v, want %v", got.Kind, test.kind)
+			}
+			if got.StatusCode != test.status {
+				t.Errorf("status = %v, want %v", got.StatusCode, test.status)
+			}
+		})
+	}
+}
+
+func TestIsRetryable(t *testing.T) {
+	tests := []struct {
+		err  *controller.SpotError
+		want bool
+	}{
+		{err: &controller.SpotError{Kind: controller.ErrSpotTimeout}, want: true},
+		{err: &controller.SpotError{Kind: controller.ErrSpotUnavailable}, want: true},
+		{err: &controller.SpotError{Kind: controller.ErrSpotBadResponse, StatusCode: http.StatusOK}},
+		{err: &controller.SpotError{Kind: controller.ErrSpotBadResponse, StatusCode: http.StatusBadRequest}},
+		{err: &controller.SpotError{Kind: controller.ErrSpotBadResponse, StatusCode: http.StatusNotFound}},
+		{err: &controller.SpotError{Kind: controller.ErrSpotBadResponse, StatusCode: http.StatusTooManyRequests},
+			want: true},
+		{err: &controller.SpotError{Kind: controller.ErrSpotBadResponse,
+			StatusCode: http.StatusInternalServerError}, want: true},
+		{err: &controller.SpotError{Kind: controller.ErrSpotBadResponse, StatusCode: http.StatusServiceUnavailable},
+			want: true},
+	}
+
+	for _, test := range tests {
+		if got := (Server{}).isRetryable(test.err); got != test.want {
+			t.Errorf("retryable %v = %v, want %v", test.err, got, test.want)
+		}
+	}
+}
+
+func TestTripsBreaker(t *testing.T) {
+	tests := []struct {
+		err  *controller.SpotError
+		want bool
+	}{
+		{err: &controller.SpotError{Kind: controller.ErrSpotTimeout}, want: true},
+		{err: &controller.SpotError{Kind: controller.ErrSpotUnavailable}, want: true},
+		{err: &controller.SpotError{Kind: controller.ErrSpotBadResponse, StatusCode: http.StatusBadRequest}},
+		{err: &controller.SpotError{Kind: controller.ErrSpotBadResponse, StatusCode: http.StatusTooManyRequests}},
+		{err: &controller.SpotError{Kind: controller.ErrSpotBadResponse, StatusCode: http.StatusBadGateway},
+			want: true},
+	}
+
+	for _, test := range tests {
+		if got := tripsBreaker(test.err); got != test.want {
+			t.Errorf("trips %v = %v, want %v", test.err, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,206 @@
+package http
+
+import (
+	"bufio"
+	"context"
+	"crypto_pro/internal/controller"
+	"crypto_pro/internal/domain/entity"
+	"crypto_pro/internal/metrics"
+	"encoding/json"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+const (
+	defaultStreamRetry = 5 * time.Second
+	defaultStreamIdle  = 60 * time.Second
+	maxStreamRetry     = 2 * time.Minute
+	maxStreamEventSize = 4 << 20
+	eventStream        = "text/event-stream"
+)
+
+type streamPolicy struct {
+	enabled bool
+	retry   time.Duration
+	idle    time.Duration
+}
+
+type spotStream struct {
+	updates   chan []entity.Transaction
+	streaming atomic.Bool
+	lastEvent string
+}
+
+func newStreamPolicy(cfg viper.Viper) streamPolicy {
+	policy := streamPolicy{enabled: cfg.GetBool("spot.stream"), retry: cfg.GetDuration("spot.stream_retry"),
+		idle: cfg.GetDuration("spot.stream_idle")}
+	if policy.retry <= 0 {
+		policy.retry = defaultStreamRetry
+	}
+	if policy.idle <= 0 {
+		policy.idle = defaultStreamIdle
+	}
+	return policy
+}
+
+func (s *spotStream) Updates() <-chan []entity.Transaction {
+	return s.updates
+}
+
+func (s *spotStream) Streaming() bool {
+	return s.streaming.Load()
+}
+
+func (s Server) SubscribeSpot(ctx context.Context, usdt, spreadMin, spreadMax float64) controller.SpotStream {
+	stream := &spotStream{updates: make(chan []entity.Transaction)}
+	if s.stream.enabled {
+		go s.runStream(ctx, stream, usdt, spreadMin, spreadMax)
+	}
+	return stream
+}
+
+func (s Server) runStream(ctx context.Context, stream *spotStream, usdt, spreadMin, spreadMax float64) {
+	retry := s.stream.retry
+	tried := map[*endpoint]bool{}
+	for {
+		endpoint := s.pool.pick(tried)
+		connected, err := s.readStream(ctx, stream, endpoint, usdt, spreadMin, spreadMax)
+		stream.streaming.Store(false)
+		if ctx.Err() != nil {
+			return
+		}
+
+		if connected {
+			retry = s.stream.retry
+			clear(tried)
+			metrics.SpotStreams.WithLabelValues("disconnected").Inc()
+		} else {
+			tried[endpoint] = true
+			if len(tried) == len(s.pool.endpoints) {
+				clear(tried)
+			}
+			metrics.SpotStreams.WithLabelValues("failed").Inc()
+		}
+		s.log.Error("spot stream is down, polling until it reconnects", s.log.StringC("Endpoint", endpoint.url),
+			s.log.ErrorC(err))
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(retry/2 + rand.N(retry/2+1)):
+		}
+		retry = min(retry*2, maxStreamRetry)
+	}
+}
+
+func (s Server) readStream(ctx context.Context, stream *spotStream, endpoint *endpoint, usdt, spreadMin,
+	spreadMax float64) (bool, error) {
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, streamURL(endpoint.url, usdt, spreadMin,
+		spreadMax), nil)
+	if err != nil {
+		return false, err
+	}
+	request.Header.Set("Accept", eventStream)
+	request.Header.Set("Cache-Control", "no-cache")
+	if stream.lastEvent != "" {
+		request.Header.Set("Last-Event-ID", stream.lastEvent)
+	}
+
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return false, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("unexpected status %v", response.StatusCode)
+	}
+	if !strings.HasPrefix(response.Header.Get("Content-Type"), eventStream) {
+		return false, fmt.Errorf("unexpected content type %q", response.Header.Get("Content-Type"))
+	}
+
+	stream.streaming.Store(true)
+	metrics.SpotStreams.WithLabelValues("connected").Inc()
+	s.log.Info("spot stream is connected", s.log.StringC("Endpoint", endpoint.url))
+
+	idle := time.AfterFunc(s.stream.idle, cancel)
+	defer idle.Stop()
+
+	scanner := bufio.NewScanner(response.Body)
+	scanner.Buffer(make([]byte, 0, 64<<10), maxStreamEventSize)
+
+	event, data := "", []string{}
+	for scanner.Scan() {
+		idle.Reset(s.stream.idle)
+
+		line := scanner.Text()
+		if line == "" {
+			idle.Stop()
+			if err := s.dispatchEvent(ctx, stream, event, data); err != nil {
+				return true, err
+			}
+			idle.Reset(s.stream.idle)
+			event, data = "", data[:0]
+			continue
+		}
+		if strings.HasPrefix(line, ":") {
+			continue
+		}
+
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "event":
+			event = value
+		case "data":
+			data = append(data, value)
+		case "id":
+			stream.lastEvent = value
+		}
+	}
+	if ctx.Err() != nil {
+		return true, fmt.Errorf("no events for %v", s.stream.idle)
+	}
+	if err := scanner.Err(); err != nil {
+		return true, err
+	}
+	return true, fmt.Errorf("stream closed by server")
+}
+
+func (s Server) dispatchEvent(ctx context.Context, stream *spotStream, event string, data []string) error {
+	if len(data) == 0 || (event != "" && event != "spot" && event != "message") {
+		return nil
+	}
+
+	transactions := Transactions{}
+	if err := json.Unmarshal([]byte(strings.Join(data, "\n")), &transactions); err != nil {
+		return &controller.SpotError{Kind: controller.ErrSpotBadResponse, Err: err}
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case stream.updates <- transactionsToEntity(transactions):
+	}
+	return nil
+}
+
+func streamURL(endpoint string, usdt, spreadMin, spreadMax float64) string {
+	query := url.Values{}
+	query.Set("usdt", strconv.FormatFloat(usdt, 'f', -1, 64))
+	query.Set("spread_min", strconv.FormatFloat(spreadMin, 'f', -1, 64))
+	query.Set("spread_max", strconv.FormatFloat(spreadMax, 'f', -1, 64))
+	return strings.TrimSuffix(endpoint, "/") + "/stream?" + query.Encode()
+}
@@ -0,0 +1,219 @@
+package http
+
+import (
+	"context"
+	"crypto_pro/internal/controller"
+	"crypto_pro/internal/domain/entity"
+	"crypto_pro/pkg/logger"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+type streamConn struct {
+	n           int
+	lastEventID string
+}
+
+type streamHandler func(w http.ResponseWriter, r *http.Request, n int)
+
+func newStreamServer(t *testing.T, enabled bool, handler streamHandler) (Server, context.Context,
+	chan streamConn) {
+
+	t.Helper()
+
+	conns := make(chan streamConn, 16)
+	var n atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/spot/stream" {
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte("[]"))
+			return
+		}
+		conn := streamConn{n: int(n.Add(1)), lastEventID: r.Header.Get("Last-Event-ID")}
+		conns <- conn
+		handler(w, r, conn.n)
+	}))
+	t.Cleanup(server.Close)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	cfg := viper.New()
+	cfg.Set("endpoint.spot_local", server.URL+"/spot")
+	cfg.Set("spot.stream", enabled)
+	cfg.Set("spot.stream_retry", 10*time.Millisecond)
+	cfg.Set("spot.stream_idle", 100*time.Millisecond)
+	return New(ctx, *cfg, logger.New(false), nil), ctx, conns
+}
+
+func writeEvent(w http.ResponseWriter, event string) {
+	w.Write([]byte(event))
+	w.(http.Flusher).Flush()
+}
+
+func startEvents(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", eventStream)
+	w.WriteHeader(http.StatusOK)
+	w.(http.Flusher).Flush()
+}
+
+func receive(t *testing.T, stream controller.SpotStream) []entity.Transaction {
+	t.Helper()
+
+	select {
+	case transactions := <-stream.Updates():
+		return transactions
+	case <-time.After(2 * time.Second):
+		t.Fatal("no update received")
+		return nil
+	}
+}
+
+func nextConn(t *testing.T, conns chan streamConn) streamConn {
+	t.Helper()
+
+	select {
+	case conn := <-conns:
+		return conn
+	case <-time.After(2 * time.Second):
+		t.Fatal("no stream connection")
+		return streamConn{}
+	}
+}
+
+func TestStreamConnect(t *testing.T) {
+	server, ctx, conns := newStreamServer(t, true, func(w http.ResponseWriter, r *http.Request, n int) {
+		startEvents(w)
+		writeEvent(w, "id: 1\nevent: spot\ndata: [{\"Symbol\":\"BTC\",\"Spread\":1.5}]\n\n")
+		<-r.Context().Done()
+	})
+
+	stream := server.SubscribeSpot(ctx, 1000, 1, 5)
+	transactions := receive(t, stream)
+	if len(transactions) != 1 || transactions[0].Symbol != "BTC" || transactions[0].Spread != 1.5 {
+		t.Fatalf("transactions = %+v", transactions)
+	}
+	if !stream.Streaming() {
+		t.Error("stream not reported as streaming")
+	}
+	if conn := nextConn(t, conns); conn.lastEventID != "" {
+		t.Errorf("first connection sent Last-Event-ID %q", conn.lastEventID)
+	}
+}
+
+func TestStreamMultilineData(t *testing.T) {
+	server, ctx, _ := newStreamServer(t, true, func(w http.ResponseWriter, r *http.Request, n int) {
+		startEvents(w)
+		writeEvent(w, ": keep-alive\n\nevent: ping\ndata: {}\n\n")
+		writeEvent(w, "data: [\ndata: {\"Symbol\":\"ETH\"},\ndata: {\"Symbol\":\"SOL\"}\ndata: ]\n\n")
+		<-r.Context().Done()
+	})
+
+	transactions := receive(t, server.SubscribeSpot(ctx, 1000, 1, 5))
+	if len(transactions) != 2 || transactions[0].Symbol != "ETH" || transactions[1].Symbol != "SOL" {
+		t.Fatalf("transactions = %+v", transactions)
+	}
+}
+
+func TestStreamReconnectSendsLastEventID(t *testing.T) {
+	server, ctx, conns := newStreamServer(t, true, func(w http.ResponseWriter, r *http.Request, n int) {
+		startEvents(w)
+		writeEvent(w, fmt.Sprintf("id: %v\ndata: []\n\n", n+6))
+		if n > 1 {
+			<-r.Context().Done()
+		}
+	})
+
+	stream := server.SubscribeSpot(ctx, 1000, 1, 5)
+	receive(t, stream)
+	receive(t, stream)
+	nextConn(t, conns)
+	if conn := nextConn(t, conns); conn.lastEventID != "7" {
+		t.Errorf("reconnect Last-Event-ID = %q, want 7", conn.lastEventID)
+	}
+}
+
+func TestStreamIdleTimeout(t *testing.T) {
+	server, ctx, conns := newStreamServer(t, true, func(w http.ResponseWriter, r *http.Request, n int) {
+		startEvents(w)
+		if n == 1 {
+			writeEvent(w, "data: []\n\n")
+		}
+		<-r.Context().Done()
+	})
+
+	stream := server.SubscribeSpot(ctx, 1000, 1, 5)
+	receive(t, stream)
+	nextConn(t, conns)
+
+	start := time.Now()
+	nextConn(t, conns)
+	if elapsed := time.Since(start); elapsed < 100*time.Millisecond {
+		t.Errorf("reconnected after %v, before idle timeout", elapsed)
+	}
+}
+
+func TestStreamNotEventStream(t *testing.T) {
+	server, ctx, conns := newStreamServer(t, true, func(w http.ResponseWriter, r *http.Request, n int) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte("[]"))
+	})
+
+	stream := server.SubscribeSpot(ctx, 1000, 1, 5)
+	nextConn(t, conns)
+	nextConn(t, conns)
+	if stream.Streaming() {
+		t.Error("non event stream reported as streaming")
+	}
+	select {
+	case transactions := <-stream.Updates():
+		t.Errorf("unexpected update %+v", transactions)
+	default:
+	}
+}
+
+func TestStreamFallbackToPolling(t *testing.T) {
+	t.Run("disabled", func(t *testing.T) {
+		server, ctx, conns := newStreamServer(t, false, func(w http.ResponseWriter, r *http.Request, n int) {})
+
+		stream := server.SubscribeSpot(ctx, 1000, 1, 5)
+		time.Sleep(50 * time.Millisecond)
+		if stream.Streaming() || len(conns) != 0 {
+			t.Error("disabled stream connected")
+		}
+	})
+
+	t.Run("disconnected", func(t *testing.T) {
+		closed := make(chan struct{})
+		server, ctx, _ := newStreamServer(t, true, func(w http.ResponseWriter, r *http.Request, n int) {
+			if n > 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			startEvents(w)
+			writeEvent(w, "data: []\n\n")
+			<-closed
+		})
+
+		stream := server.SubscribeSpot(ctx, 1000, 1, 5)
+		receive(t, stream)
+		if !stream.Streaming() {
+			t.Fatal("stream not reported as streaming")
+		}
+		close(closed)
+
+		deadline := time.Now().Add(2 * time.Second)
+		for stream.Streaming() && time.Now().Before(deadline) {
+			time.Sleep(10 * time.Millisecond)
+		}
+		if stream.Streaming() {
+			t.Error("stream still reported as streaming after disconnect")
+		}
+	})
+}
@@ -0,0 +1,111 @@
+package resilience
+
+import (
+	"context"
+	"crypto_pro/internal/controller"
+	"crypto_pro/pkg/logger"
+	"math/rand/v2"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+const (
+	defaultTimeout = 15 * time.Second
+	defaultRetries = 2
+	defaultBackoff = 500 * time.Millisecond
+)
+
+type Policy struct {
+	Timeout time.Duration
+	Retries int
+	Backoff time.Duration
+}
+
+// Call is a single spot request made by Retrier.Do. Attempt returns nil on
+// success, Retryable decides whether a failed attempt is repeated and Trips
+// whether the final error counts against the circuit breaker.
+type Call struct {
+	Attempt   func(ctx context.Context) *controller.SpotError
+	Retryable func(err *controller.SpotError) bool
+	Trips     func(err *controller.SpotError) bool
+}
+
+type Retrier struct {
+	ctx     context.Context
+	log     logger.Logger
+	policy  Policy
+	breaker *Breaker
+}
+
+func NewPolicy(cfg viper.Viper) Policy {
+	policy := Policy{Timeout: cfg.GetDuration("spot.timeout"), Retries: cfg.GetInt("spot.retries"),
+		Backoff: cfg.GetDuration("spot.backoff")}
+	if policy.Timeout <= 0 {
+		policy.Timeout = defaultTimeout
+	}
+	if !cfg.IsSet("spot.retries") {
+		policy.Retries = defaultRetries
+	}
+	policy.Retries = max(policy.Retries, 0)
+	if policy.Backoff <= 0 {
+		policy.Backoff = defaultBackoff
+	}
+	return policy
+}
+
+func New(ctx context.Context, log logger.Logger, policy Policy, breaker *Breaker) *Retrier {
+	return &Retrier{ctx: ctx, log: log, policy: policy, breaker: breaker}
+}
+
+func (p Policy) Delay(attempt int) time.Duration {
+	backoff := p.Backoff << attempt
+	return backoff/2 + rand.N(backoff/2+1)
+}
+
+func (r *Retrier) Do(call Call) error {
+	if !r.breaker.Allow() {
+		return &controller.SpotError{Kind: controller.ErrSpotCircuitOpen}
+	}
+
+	var spotErr *controller.SpotError
+	for attempt := 0; attempt <= r.policy.Retries; attempt++ {
+		if attempt > 0 && !Sleep(r.ctx, r.policy.Delay(attempt-1)) {
+			break
+		}
+
+		ctx, cancel := context.WithTimeout(r.ctx, r.policy.Timeout)
+		err := call.Attempt(ctx)
+		cancel()
+		if err == nil {
+			r.breaker.Success()
+			return nil
+		}
+
+		spotErr = err
+		spotErr.Attempts = attempt + 1
+		if !call.Retryable(spotErr) {
+			break
+		}
+		r.log.Info("retry spot request", r.log.ErrorC(spotErr), r.log.IntC("Attempt", attempt+1))
+	}
+
+	if call.Trips(spotErr) {
+		r.breaker.Failure()
+	} else {
+		r.breaker.Success()
+	}
+	return spotErr
+}
+
+func Sleep(ctx context.Context, delay time.Duration) bool {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
@@ -0,0 +1,198 @@
+package resilience
+
+import (
+	"context"
+	"crypto_pro/internal/controller"
+	"crypto_pro/pkg/logger"
+	"errors"
+	"testing"
+	"time"
+)
+
+func newRetrier(ctx context.Context, retries int, breaker *Breaker) *Retrier {
+	return New(ctx, logger.New(false), Policy{Timeout: time.Second, Retries: retries,
+		Backoff: time.Millisecond}, breaker)
+}
+
+func failingCall(calls *int, err *controller.SpotError, retryable, trips bool) Call {
+	return Call{
+		Attempt: func(ctx context.Context) *controller.SpotError {
+			*calls++
+			return &controller.SpotError{Kind: err.Kind, StatusCode: err.StatusCode}
+		},
+		Retryable: func(*controller.SpotError) bool { return retryable },
+		Trips:     func(*controller.SpotError) bool { return trips },
+	}
+}
+
+func TestDoSucceedsAfterRetry(t *testing.T) {
+	calls := 0
+	err := newRetrier(context.Background(), 2, newBreaker(1, time.Hour)).Do(Call{
+		Attempt: func(ctx context.Context) *controller.SpotError {
+			calls++
+			if _, ok := ctx.Deadline(); !ok {
+				t.Error("attempt without timeout")
+			}
+			if calls == 1 {
+				return &controller.SpotError{Kind: controller.ErrSpotUnavailable}
+			}
+			return nil
+		},
+		Retryable: func(*controller.SpotError) bool { return true },
+		Trips:     func(*controller.SpotError) bool { return true },
+	})
+	if err != nil || calls != 2 {
+		t.Fatalf("err = %v, calls = %v", err, calls)
+	}
+}
+
+func TestDoStopsOnNonRetryable(t *testing.T) {
+	calls := 0
+	err := newRetrier(context.Background(), 3, newBreaker(1, time.Hour)).Do(failingCall(&calls,
+		&controller.SpotError{Kind: controller.ErrSpotBadResponse, StatusCode: 400}, false, false))
+
+	var spotErr *controller.SpotError
+	if !errors.As(err, &spotErr) || !errors.Is(err, controller.ErrSpotBadResponse) || spotErr.Attempts != 1 {
+		t.Fatalf("err = %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("calls = %v, want 1", calls)
+	}
+}
+
+func TestDoExhaustsRetries(t *testing.T) {
+	calls := 0
+	err := newRetrier(context.Background(), 2, newBreaker(5, time.Hour)).Do(failingCall(&calls,
+		&controller.SpotError{Kind: controller.ErrSpotUnavailable}, true, true))
+
+	var spotErr *controller.SpotError
+	if !errors.As(err, &spotErr) || spotErr.Attempts != 3 || calls != 3 {
+		t.Fatalf("err = %v, calls = %v", err, calls)
+	}
+}
+
+func TestDoOpensBreaker(t *testing.T) {
+	calls := 0
+	retrier := newRetrier(context.Background(), 0, newBreaker(2, time.Hour))
+	call := failingCall(&calls, &controller.SpotError{Kind: controller.ErrSpotUnavailable}, true, true)
+
+	retrier.Do(call)
+	retrier.Do(call)
+	if err := retrier.Do(call); !errors.Is(err, controller.ErrSpotCircuitOpen) {
+		t.Fatalf("err = %v, want circuit open", err)
+	}
+	if calls != 2 {
+		t.Errorf("calls = %v, want 2", calls)
+	}
+}
+
+func TestDoCallerErrorsKeepBreakerClosed(t *testing.T) {
+	calls := 0
+	retrier := newRetrier(context.Background(), 0, newBreaker(1, time.Hour))
+	call := failingCall(&calls, &controller.SpotError{Kind: controller.ErrSpotBadResponse, StatusCode: 400},
+		false, false)
+
+	for i := 0; i < 3; i++ {
+		if err := retrier.Do(call); errors.Is(err, controller.ErrSpotCircuitOpen) {
+			t.Fatal("breaker opened on caller error")
+		}
+	}
+}
+
+func TestDoStopsOnCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+	retrier := New(ctx, logger.New(false), Policy{Timeout: time.Second, Retries: 5, Backoff: time.Hour},
+		newBreaker(5, time.Hour))
+	call := failingCall(&calls, &controller.SpotError{Kind: controller.ErrSpotUnavailable}, true, true)
+	cancel()
+
+	start := time.Now()
+	if err := retrier.Do(call); !errors.Is(err, controller.ErrSpotUnavailable) {
+		t.Fatalf("err = %v", err)
+	}
+	if calls != 1 || time.Since(start) > time.Second {
+		t.Errorf("calls = %v after %v, want one attempt without waiting", calls, time.Since(start))
+	}
+}
+
+func TestDelayJitter(t *testing.T) {
+	policy := Policy{Backoff: 100 * time.Millisecond}
+	for attempt := 0; attempt < 4; attempt++ {
+		backoff := policy.Backoff << attempt
+		for i := 0; i < 100; i++ {
+			if delay := policy.Delay(attempt); delay < backoff/2 || delay > backoff {
+				t.Fatalf("Delay(%v) = %v, want within [%v, %v]", attempt, delay, backoff/2, backoff)
+			}
+		}
+	}
+}
+
+func TestBreakerOpensAtThreshold(t *testing.T) {
+	breaker := newBreaker(3, time.Hour)
+	for i := 0; i < 2; i++ {
+		breaker.Failure()
+		if !breaker.Allow() {
+			t.Fatalf("breaker open after %v failures", i+1)
+		}
+	}
+	breaker.Failure()
+	if breaker.Allow() {
+		t.Fatal("breaker closed after reaching threshold")
+	}
+}
+
+func TestBreakerSuccessResets(t *testing.T) {
+	breaker := newBreaker(2, time.Hour)
+	breaker.Failure()
+	breaker.Success()
+	breaker.Failure()
+	if !breaker.Allow() {
+		t.Fatal("failures not reset by success")
+	}
+}
+
+func TestBreakerHalfOpen(t *testing.T) {
+	breaker := newBreaker(1, 10*time.Millisecond)
+	breaker.Failure()
+	if breaker.Allow() {
+		t.Fatal("breaker allowed request during cooldown")
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	if !breaker.Allow() {
+		t.Fatal("breaker rejected probe after cooldown")
+	}
+	if breaker.Allow() {
+		t.Fatal("breaker allowed second request while probe is in flight")
+	}
+
+	breaker.Failure()
+	if breaker.Allow() {
+		t.Fatal("breaker allowed request after failed probe")
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	if !breaker.Allow() {
+		t.Fatal("breaker rejected probe after second cooldown")
+	}
+	breaker.Success()
+	for i := 0; i < 3; i++ {
+		if !breaker.Allow() {
+			t.Fatal("breaker not closed after successful probe")
+		}
+	}
+}
+
+func TestSleepCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	start := time.Now()
+	if Sleep(ctx, time.Hour) {
+		t.Fatal("Sleep completed after ctx cancellation")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("Sleep took %v after ctx cancellation", elapsed)
+	}
+}
@@ -5,7 +5,22 @@ import (
 	"time"
 )
 
+const (
+	hideCallbackPrefix     = "hide/"
+	chartCallbackPrefix    = "chart/"
+	favoriteCallbackPrefix = "fav/"
+	refreshCallbackPrefix  = "refresh/"
+	expiredCallbackPrefix  = "expired/"
+)
+
+const (
+	maxCallbackData = 64
+	maxSessions     = 30
+	maxUserSessions = 5
+)
+
 type clientUpdate struct {
 	cancelFunc context.CancelFunc
 	time       time.Time
+	edited     chan struct{}
 }
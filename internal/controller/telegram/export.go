@@ -0,0 +1,49 @@
+package telegram
+
+import (
+	"crypto_pro/internal/domain/entity"
+	"crypto_pro/internal/export"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+func (t TelegramController) exportTransactions(update tgbotapi.Update, keyboard tgbotapi.ReplyKeyboardMarkup) {
+	id, args, err := t.getSessionFromArgs(update.Message, update.Message.CommandArguments())
+	if err != nil {
+		t.sendMessage(err.Error(), update, keyboard)
+		return
+	}
+
+	filter, err := t.taskUseCase.GetExportFilter(args)
+	if err != nil {
+		t.sendMessage(err.Error(), update, keyboard)
+		return
+	}
+
+	_, _, name := entity.ParseSessionID(id)
+	fileName := fmt.Sprintf("deals_%v_%v.%v", name, time.Now().UTC().Format("20060102_1504"), filter.Format)
+	caption := fmt.Sprintf("📄 Сделки сессии %v с %v UTC", name, filter.Since.UTC().Format("02.01 15:04"))
+
+	reader, writer := io.Pipe()
+	exported := make(chan error, 1)
+	go func() {
+		err := t.taskUseCase.ExportTransactions(id, filter, writer)
+		exported <- err
+		writer.CloseWithError(err)
+	}()
+
+	if err := t.sendDocument(update.Message, fileName, caption, reader); err != nil {
+		t.log.Error("failed to send export", t.log.ErrorC(err))
+		reader.CloseWithError(err)
+		if errors.Is(<-exported, export.ErrTooManyRows) {
+			t.sendMessage(fmt.Sprintf("В XLSX помещается не больше %v сделок. Сузьте период или выгрузите "+
+				"в CSV: /export csv", export.MaxXLSXRows), update, keyboard)
+			return
+		}
+		t.sendMessage("Не удалось выгрузить сделки.", update, keyboard)
+	}
+}
@@ -0,0 +1,146 @@
+package telegram
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+var errNotAdmin = errors.New("Управлять чужими сессиями могут только администраторы группы.")
+
+type threadStore struct {
+	threads sync.Map
+}
+
+func (t TelegramController) isForOtherBot(message *tgbotapi.Message) bool {
+	_, botName, found := strings.Cut(message.CommandWithAt(), "@")
+	return found && !strings.EqualFold(botName, t.bot.Self.UserName)
+}
+
+func (t TelegramController) isAdmin(chat *tgbotapi.Chat, userID int64) bool {
+	if chat.IsPrivate() {
+		return true
+	}
+
+	member, err := t.bot.GetChatMember(tgbotapi.GetChatMemberConfig{
+		ChatConfigWithUser: tgbotapi.ChatConfigWithUser{ChatID: chat.ID, UserID: userID},
+	})
+	if err != nil {
+		t.log.Error("failed to get chat member", t.log.ErrorC(err))
+		return false
+	}
+	return member.IsAdministrator() || member.IsCreator()
+}
+
+func (t TelegramController) getTextFromMessage(message *tgbotapi.Message) string {
+	args := message.CommandArguments()
+	switch message.Command() {
+	case "start", "help":
+		return "Инструкция"
+	case "run":
+		return args
+	case "stop":
+		return strings.TrimSpace("stop " + args)
+	case "all":
+		return strings.TrimSpace("all " + args)
+	}
+	return message.Text
+}
+
+func (t TelegramController) setThread(message *tgbotapi.Message) string {
+	if !t.isAdmin(message.Chat, message.From.ID) {
+		return errNotAdmin.Error()
+	}
+
+	args := strings.TrimSpace(message.CommandArguments())
+	threadID := 0
+	if args != "off" {
+		parsed, err := strconv.Atoi(args)
+		if err != nil || parsed <= 0 {
+			return "Укажите номер темы: /topic 42 или /topic off. Номер темы — первое число после " +
+				"номера чата в ссылке на сообщение из нее: t.me/c/<чат>/<тема>/<сообщение>"
+		}
+		threadID = parsed
+	}
+
+	msgContent := t.taskUseCase.SetChatThread(strconv.FormatInt(message.Chat.ID, 10), threadID)
+	t.threads.threads.Store(message.Chat.ID, threadID)
+	return msgContent
+}
+
+func (t TelegramController) getThread(chatID int64) int {
+	if threadID, exists := t.threads.threads.Load(chatID); exists {
+		return threadID.(int)
+	}
+	threadID := t.taskUseCase.GetChatThread(strconv.FormatInt(chatID, 10))
+	t.threads.threads.Store(chatID, threadID)
+	return threadID
+}
+
+func (t TelegramController) sendPhoto(chatID int64, caption string, image []byte) error {
+	params := tgbotapi.Params{}
+	params.AddNonZero64("chat_id", chatID)
+	params.AddNonEmpty("caption", caption)
+	params.AddNonZero("message_thread_id", t.getThread(chatID))
+
+	_, err := t.bot.UploadFiles("sendPhoto", params, []tgbotapi.RequestFile{
+		{Name: "photo", Data: tgbotapi.FileBytes{Name: "chart.png", Bytes: image}},
+	})
+	return err
+}
+
+func (t TelegramController) sendDocument(message *tgbotapi.Message, name, caption string,
+	reader io.Reader) error {
+	params := tgbotapi.Params{}
+	params.AddNonZero64("chat_id", message.Chat.ID)
+	params.AddNonEmpty("caption", caption)
+	params.AddNonZero("message_thread_id", t.getThread(message.Chat.ID))
+	if !message.Chat.IsPrivate() {
+		params.AddNonZero("reply_to_message_id", message.MessageID)
+	}
+
+	_, err := t.bot.UploadFiles("sendDocument", params, []tgbotapi.RequestFile{
+		{Name: "document", Data: tgbotapi.FileReader{Name: name, Reader: reader}},
+	})
+	return err
+}
+
+func (t TelegramController) SendMessage(chatID, text string) error {
+	id, err := strconv.ParseInt(chatID, 10, 64)
+	if err != nil {
+		return err
+	}
+	_, err = t.send(tgbotapi.NewMessage(id, text))
+	return err
+}
+
+func (t TelegramController) send(msg tgbotapi.MessageConfig) (tgbotapi.Message, error) {
+	threadID := t.getThread(msg.ChatID)
+	if threadID == 0 {
+		return t.bot.Send(msg)
+	}
+
+	params := tgbotapi.Params{}
+	params.AddFirstValid("chat_id", msg.ChatID, msg.ChannelUsername)
+	params.AddNonEmpty("text", msg.Text)
+	params.AddNonEmpty("parse_mode", msg.ParseMode)
+	params.AddNonZero("message_thread_id", threadID)
+	params.AddNonZero("reply_to_message_id", msg.ReplyToMessageID)
+	if err := params.AddInterface("reply_markup", msg.ReplyMarkup); err != nil {
+		return tgbotapi.Message{}, err
+	}
+
+	resp, err := t.bot.MakeRequest("sendMessage", params)
+	if err != nil {
+		return tgbotapi.Message{}, err
+	}
+
+	var message tgbotapi.Message
+	err = json.Unmarshal(resp.Result, &message)
+	return message, err
+}
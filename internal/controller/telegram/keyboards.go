@@ -0,0 +1,60 @@
+package telegram
+
+import (
+	"sync"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+const maxPostedKeyboards = 50
+
+type postedKeyboard struct {
+	messageID int
+	markup    tgbotapi.InlineKeyboardMarkup
+}
+
+type keyboardStore struct {
+	mu        sync.Mutex
+	keyboards map[string][]postedKeyboard
+}
+
+func newKeyboardStore() *keyboardStore {
+	return &keyboardStore{keyboards: make(map[string][]postedKeyboard)}
+}
+
+func (k *keyboardStore) add(id string, messageID int, markup tgbotapi.InlineKeyboardMarkup) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	keyboards := append(k.keyboards[id], postedKeyboard{messageID: messageID, markup: markup})
+	if len(keyboards) > maxPostedKeyboards {
+		keyboards = keyboards[len(keyboards)-maxPostedKeyboards:]
+	}
+	k.keyboards[id] = keyboards
+}
+
+func (k *keyboardStore) expire(id string, data, text string) (postedKeyboard, bool) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	keyboards := k.keyboards[id]
+	for i := len(keyboards) - 1; i >= 0; i-- {
+		for _, row := range keyboards[i].markup.InlineKeyboard {
+			for j := range row {
+				if row[j].CallbackData == nil || *row[j].CallbackData != data {
+					continue
+				}
+				row[j].Text = text
+				expiredData := expiredCallbackPrefix
+				row[j].CallbackData = &expiredData
+				return keyboards[i], true
+			}
+		}
+	}
+	return postedKeyboard{}, false
+}
+
+func (k *keyboardStore) delete(id string) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	delete(k.keyboards, id)
+}
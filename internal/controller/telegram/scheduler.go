@@ -0,0 +1,83 @@
+package telegram
+
+import (
+	"context"
+	"crypto_pro/internal/domain/entity"
+	"errors"
+	"strconv"
+	"time"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+func (t TelegramController) notifyFavorites() {
+	for _, alert := range t.taskUseCase.CheckFavorites() {
+		chatID, err := strconv.ParseInt(alert.ChatID, 10, 64)
+		if err != nil {
+			t.log.Error("invalid favorite chat id", t.log.ErrorC(err))
+			continue
+		}
+		msg := tgbotapi.NewMessage(chatID, alert.Text)
+		msg.ParseMode = t.taskUseCase.GetCardParseMode()
+		t.send(msg)
+	}
+}
+
+func (t TelegramController) runFavorites(ctx context.Context) {
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			t.notifyFavorites()
+		}
+	}
+}
+
+func (t TelegramController) runScheduler(ctx context.Context) {
+	lastActive := make(map[int64]bool)
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+
+	for now := time.Now(); ; now = <-ticker.C {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+
+		for _, schedule := range t.taskUseCase.CheckSchedules(now) {
+			chat, ownerID, name := entity.ParseSessionID(schedule.SessionID)
+			chatID, err := strconv.ParseInt(chat, 10, 64)
+			if err != nil {
+				t.log.Error("invalid schedule session id", t.log.ErrorC(err))
+				continue
+			}
+
+			wasActive, known := lastActive[schedule.ID]
+			lastActive[schedule.ID] = schedule.Active
+			if known && wasActive == schedule.Active {
+				continue
+			}
+
+			switch {
+			case schedule.Active:
+				err := t.startSession(chatID, entity.NewSessionID(chat, ownerID, name), schedule.Request)
+				switch {
+				case err == nil:
+					t.send(tgbotapi.NewMessage(chatID, "🗓 Сессия "+name+" ("+schedule.Request+
+						") начата по расписанию. Отправьте 'stop' для отмены."))
+				case !errors.Is(err, errSessionActive):
+					t.send(tgbotapi.NewMessage(chatID, "🗓 Сессия "+name+" не начата по расписанию. "+err.Error()))
+				}
+			case known:
+				if t.stopSession(entity.NewSessionID(chat, ownerID, name)) {
+					t.send(tgbotapi.NewMessage(chatID, "🗓 Сессия "+name+" остановлена по расписанию."))
+				}
+			}
+		}
+	}
+}
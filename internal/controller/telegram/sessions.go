@@ -0,0 +1,76 @@
+package telegram
+
+import (
+	"crypto_pro/internal/domain/entity"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+)
+
+var (
+	errSessionActive    = errors.New("Сессия уже активна.")
+	errTooManySessions  = errors.New("Слишком много сессий, попробуйте позже.")
+	errUserSessionLimit = fmt.Errorf("Можно запустить не более %v сессий одновременно.", maxUserSessions)
+)
+
+type sessionStore struct {
+	mu       sync.Mutex
+	sessions map[string]clientUpdate
+}
+
+func newSessionStore() *sessionStore {
+	return &sessionStore{sessions: make(map[string]clientUpdate)}
+}
+
+func (s *sessionStore) add(id string, session clientUpdate) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exists := s.sessions[id]; exists {
+		return errSessionActive
+	}
+
+	_, ownerID, _ := entity.ParseSessionID(id)
+	owned := 0
+	for sessionID := range s.sessions {
+		if _, owner, _ := entity.ParseSessionID(sessionID); owner == ownerID {
+			owned++
+		}
+	}
+	if owned >= maxUserSessions {
+		return errUserSessionLimit
+	}
+
+	s.sessions[id] = session
+	return nil
+}
+
+func (s *sessionStore) get(id string) (clientUpdate, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	session, exists := s.sessions[id]
+	return session, exists
+}
+
+func (s *sessionStore) remove(id string) (clientUpdate, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	session, exists := s.sessions[id]
+	delete(s.sessions, id)
+	return session, exists
+}
+
+func (s *sessionStore) names(prefix string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	names := []string{}
+	for id := range s.sessions {
+		if name, found := strings.CutPrefix(id, prefix); found {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	return names
+}
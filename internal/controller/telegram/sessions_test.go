@@ -0,0 +1,108 @@
+package telegram
+
+import (
+	"crypto_pro/internal/domain/entity"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+func TestSessionStoreAdd(t *testing.T) {
+	sessions := newSessionStore()
+	for i := 0; i < maxUserSessions; i++ {
+		if err := sessions.add(entity.NewSessionID("-100", "42", fmt.Sprint("s", i)), clientUpdate{}); err != nil {
+			t.Fatalf("add session %v: %v", i, err)
+		}
+	}
+
+	if err := sessions.add(entity.NewSessionID("-100", "42", "s0"), clientUpdate{}); !errors.Is(err, errSessionActive) {
+		t.Errorf("add duplicate = %v, want %v", err, errSessionActive)
+	}
+	if err := sessions.add(entity.NewSessionID("7", "42", "extra"), clientUpdate{}); !errors.Is(err, errUserSessionLimit) {
+		t.Errorf("add over limit in another chat = %v, want %v", err, errUserSessionLimit)
+	}
+	if err := sessions.add(entity.NewSessionID("-100", "43", "s0"), clientUpdate{}); err != nil {
+		t.Errorf("add for another owner = %v", err)
+	}
+
+	sessions.remove(entity.NewSessionID("-100", "42", "s0"))
+	if err := sessions.add(entity.NewSessionID("7", "42", "extra"), clientUpdate{}); err != nil {
+		t.Errorf("add after remove = %v", err)
+	}
+}
+
+func TestGetKeyFromUpdate(t *testing.T) {
+	tests := []struct {
+		data string
+		want []string
+		ok   bool
+	}{
+		{data: "BYBIT/MEXC/BTC", want: []string{entity.DefaultSessionName, "BYBIT", "MEXC", "BTC"}, ok: true},
+		{data: "42:big/BYBIT/MEXC/BTC", want: []string{"42:big", "BYBIT", "MEXC", "BTC"}, ok: true},
+		{data: "BTC"},
+		{data: "BYBIT/MEXC"},
+		{data: "42:big/BYBIT/MEXC/BTC/ETH"},
+	}
+
+	for _, test := range tests {
+		session, marketFrom, marketTo, symbol, ok := TelegramController{}.getKeyFromUpdate(
+			&tgbotapi.CallbackQuery{Data: test.data})
+		if ok != test.ok {
+			t.Errorf("getKeyFromUpdate(%v) ok = %v, want %v", test.data, ok, test.ok)
+			continue
+		}
+		if got := []string{session, marketFrom, marketTo, symbol}; ok && !slices.Equal(got, test.want) {
+			t.Errorf("getKeyFromUpdate(%v) = %v, want %v", test.data, got, test.want)
+		}
+	}
+}
+
+func TestGetKeyMsgRoundTrip(t *testing.T) {
+	id := entity.NewSessionID("-1001234567890", "1234567890", "night_shift")
+	transaction := entity.Transaction{Symbol: "PEPE", MarketFrom: "ASCENDEX", MarketTo: "BITMART"}
+
+	key := TelegramController{}.getKeyMsg(id, transaction)
+	if len(key) > maxCallbackData {
+		t.Fatalf("key %v is %v bytes, limit %v", key, len(key), maxCallbackData)
+	}
+	session, marketFrom, marketTo, symbol, ok := TelegramController{}.getKeyFromUpdate(
+		&tgbotapi.CallbackQuery{Data: key})
+	if !ok || session != "1234567890:night_shift" || marketFrom != "ASCENDEX" || marketTo != "BITMART" ||
+		symbol != "PEPE" {
+		t.Errorf("round trip of %v = %v %v %v %v %v", key, session, marketFrom, marketTo, symbol, ok)
+	}
+}
+
+func TestGetCardKeyboardChart(t *testing.T) {
+	tests := []struct {
+		name  string
+		key   string
+		chain string
+		want  string
+	}{
+		{name: "chain in chart key", key: "42:big/BYBIT/MEXC/BTC", chain: "TRC20",
+			want: chartCallbackPrefix + "TRC20/42:big/BYBIT/MEXC/BTC"},
+		{name: "no chain", key: "42:big/BYBIT/MEXC/BTC"},
+		{name: "chain with separator", key: "42:big/BYBIT/MEXC/BTC", chain: "BEP20/BSC"},
+		{name: "too long", key: "1234567890:night_shift/ASCENDEX/BITMART/BABYDOGECOIN", chain: "ARBITRUM"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			markup := TelegramController{}.getCardKeyboard(test.key, "BTC", test.chain)
+			got := ""
+			for _, button := range markup.InlineKeyboard[0] {
+				if data := *button.CallbackData; strings.HasPrefix(data, chartCallbackPrefix) {
+					got = data
+				}
+			}
+			if got != test.want {
+				t.Errorf("chart data = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
@@ -6,9 +6,11 @@ import (
 	"crypto_pro/internal/domain/entity"
 	"crypto_pro/internal/domain/usecase"
 	"crypto_pro/pkg/logger"
+	"errors"
 	"fmt"
 	"os"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -23,6 +25,10 @@ type TelegramController struct {
 	bot         *tgbotapi.BotAPI
 	updates     tgbotapi.UpdateConfig
 	taskUseCase usecase.TaskUseCase
+	keyboards   *keyboardStore
+	sessions    *sessionStore
+	threads     *threadStore
+	semaphore   chan struct{}
 }
 
 func New(log logger.Logger, taskUseCase usecase.TaskUseCase) TelegramController {
@@ -43,7 +49,9 @@ func New(log logger.Logger, taskUseCase usecase.TaskUseCase) TelegramController
 	updates := tgbotapi.NewUpdate(0)
 	updates.Timeout = 60
 
-	telegram := TelegramController{log: log, bot: bot, taskUseCase: taskUseCase, updates: updates}
+	telegram := TelegramController{log: log, bot: bot, taskUseCase: taskUseCase, updates: updates,
+		keyboards: newKeyboardStore(), sessions: newSessionStore(), threads: &threadStore{},
+		semaphore: make(chan struct{}, maxSessions)}
 	telegram.taskUseCase.TrancateRawTransactions()
 	telegram.taskUseCase.TrancateDwhTransactions()
 
@@ -51,71 +59,189 @@ func New(log logger.Logger, taskUseCase usecase.TaskUseCase) TelegramController
 }
 
 func (t TelegramController) Run(ctx context.Context) {
-	var activeSessions = make(map[int64]clientUpdate)
-
 	updates := t.bot.GetUpdatesChan(t.updates)
 	keyboard := tgbotapi.NewReplyKeyboard(tgbotapi.NewKeyboardButtonRow(
 		tgbotapi.NewKeyboardButton("Инструкция")))
-	re := regexp.MustCompile(`^\d+\s\d+(\.\d+)?\s\d+(\.\d+)?$`)
-	semathore := make(chan struct{}, 30)
-	defer close(semathore)
+	re := regexp.MustCompile(`^\d+\s\d+(\.\d+)?\s\d+(\.\d+)?(\s[A-Za-z0-9_-]{1,16})?$`)
+
+	go t.runScheduler(ctx)
+	go t.runFavorites(ctx)
 
 	for update := range updates {
 		if update.Message != nil {
 			t.log.Info("Received message", t.log.StringC("Message", update.Message.Text),
 				t.log.Int64C("ChatID", update.Message.Chat.ID), t.log.IntC("Semaphore",
-					len(semathore)))
+					len(t.semaphore)))
+
+			if t.isForOtherBot(update.Message) {
+				continue
+			}
+
+			chatID, ownerID := t.getOwnerFromMessage(update.Message)
+			text := t.getTextFromMessage(update.Message)
 
 			switch {
-			case update.Message.Text == "Инструкция":
+			case text == "Инструкция":
 				t.sendMessage(t.taskUseCase.GetInstruction(), update, keyboard)
-			case re.MatchString(update.Message.Text):
-				if _, exists := activeSessions[update.Message.Chat.ID]; exists {
-					t.sendMessage("Сессия активна", update, keyboard)
-					continue
+			case re.MatchString(text) && (update.Message.Chat.IsPrivate() || update.Message.Command() == "run"):
+				fields := strings.Fields(text)
+				name := entity.DefaultSessionName
+				if len(fields) == 4 {
+					name = fields[3]
 				}
 
-				t.taskUseCase.CreateSession(strconv.Itoa(int(update.Message.Chat.ID)), update.Message.Text)
-				ctx, cancelFunc := context.WithCancel(context.Background())
-				activeSessions[update.Message.Chat.ID] = clientUpdate{
-					cancelFunc: cancelFunc,
-					time:       time.Now(),
+				id := entity.NewSessionID(chatID, ownerID, name)
+				if err := t.startSession(update.Message.Chat.ID, id, strings.Join(fields[:3], " ")); err != nil {
+					if errors.Is(err, errSessionActive) {
+						t.sendMessage(fmt.Sprintf("Сессия %v активна", name), update, keyboard)
+						continue
+					}
+					t.sendMessage(err.Error(), update, keyboard)
+					continue
 				}
+				t.sendMessage(fmt.Sprintf("Сессия %v начата. Отправьте 'stop' для отмены.", name), update,
+					keyboard)
 
-				semathore <- struct{}{}
-				go func(ctx context.Context) {
-					defer func() { <-semathore }()
-					ticker := time.NewTicker(time.Second * 120)
-					for timeT := time.Now(); ; timeT = <-ticker.C {
-						_ = timeT
-
-						select {
-						case <-ctx.Done():
-							return
-						default:
-							t.handleRequest(update)
-						}
+			case text == "stop" || strings.HasPrefix(text, "stop "):
+				t.sendMessage(t.stopSessions(update.Message, strings.TrimPrefix(text, "stop")), update, keyboard)
+
+			case update.Message.Command() == "sessions":
+				prefix := entity.SessionPrefix(chatID, ownerID)
+				if update.Message.CommandArguments() == "all" {
+					if !t.isAdmin(update.Message.Chat, update.Message.From.ID) {
+						t.sendMessage(errNotAdmin.Error(), update, keyboard)
+						continue
 					}
-				}(ctx)
-				t.sendMessage("Сессия начата. Отправьте 'stop' для отмены.", update, keyboard)
-
-			case update.Message.Text == "stop":
-				if clientUpdate, exists := activeSessions[update.Message.Chat.ID]; exists {
-					clientUpdate.cancelFunc()
-					delete(activeSessions, update.Message.Chat.ID)
-					t.taskUseCase.DeleteSession(strconv.Itoa(int(update.Message.Chat.ID)))
-					t.sendMessage("Сессия отменена.", update, keyboard)
-				} else {
+					prefix = chatID + ":"
+				}
+
+				names := t.sessions.names(prefix)
+				if len(names) == 0 {
 					t.sendMessage("Нет активной сессии.", update, keyboard)
+					continue
+				}
+				for _, name := range names {
+					t.sendMessage(t.taskUseCase.GetStatus(prefix+name), update, keyboard)
 				}
 
-			case update.Message.Text == "all":
-				transactions := t.taskUseCase.GetAllTransactions(strconv.Itoa(int(update.Message.Chat.ID)))
+			case update.Message.Command() == "edit":
+				t.sessionCommand(update, keyboard, func(id, requestIn string) string {
+					if !re.MatchString(requestIn) {
+						return "Пример: /edit big 5000 1 5"
+					}
+					defer t.resubscribe(id)
+					return t.taskUseCase.EditSession(id, requestIn)
+				})
+
+			case update.Message.Command() == "export":
+				t.exportTransactions(update, keyboard)
+
+			case update.Message.Command() == "topic":
+				t.sendMessage(t.setThread(update.Message), update, keyboard)
+
+			case update.Message.Command() == "digest":
+				t.sendMessage(t.taskUseCase.SetDigest(chatID, ownerID, update.Message.CommandArguments()), update,
+					keyboard)
+
+			case update.Message.Command() == "tz":
+				t.sendMessage(t.taskUseCase.SetTimeZone(ownerID, update.Message.CommandArguments()), update,
+					keyboard)
+
+			case update.Message.Command() == "quiet":
+				t.sendMessage(t.taskUseCase.SetQuietHours(ownerID, update.Message.CommandArguments()), update,
+					keyboard)
+
+			case update.Message.Command() == "schedule":
+				if update.Message.CommandArguments() == "" {
+					t.sendMessage(t.taskUseCase.GetSchedules(chatID, ownerID), update, keyboard)
+					continue
+				}
+				t.sendMessage(t.taskUseCase.AddSchedule(chatID, ownerID, update.Message.CommandArguments()), update,
+					keyboard)
+
+			case update.Message.Command() == "unschedule":
+				msgContent, sessionID := t.taskUseCase.DeleteSchedule(chatID, ownerID,
+					update.Message.CommandArguments())
+				if sessionID != "" && t.stopSession(sessionID) {
+					msgContent += " Сессия по нему остановлена."
+				}
+				t.sendMessage(msgContent, update, keyboard)
+
+			case update.Message.Command() == "status":
+				t.sessionCommand(update, keyboard, func(id, _ string) string {
+					return t.taskUseCase.GetStatus(id)
+				})
+
+			case update.Message.Command() == "buy":
+				t.sessionCommand(update, keyboard, t.taskUseCase.SetBuyMarkets)
+
+			case update.Message.Command() == "sell":
+				t.sessionCommand(update, keyboard, t.taskUseCase.SetSellMarkets)
+
+			case update.Message.Command() == "chains":
+				t.sessionCommand(update, keyboard, t.taskUseCase.SetIncludeChains)
+
+			case update.Message.Command() == "nochains":
+				t.sessionCommand(update, keyboard, t.taskUseCase.SetExcludeChains)
+
+			case update.Message.Command() == "guards":
+				t.sessionCommand(update, keyboard, t.taskUseCase.SetGuards)
+
+			case update.Message.Command() == "alerts":
+				t.sessionCommand(update, keyboard, t.taskUseCase.SetAlerts)
+
+			case update.Message.Command() == "closed":
+				t.sessionCommand(update, keyboard, t.taskUseCase.SetNotifyClosed)
+
+			case update.Message.Command() == "hide":
+				t.sendMessage(t.taskUseCase.HideSymbol(ownerID, update.Message.CommandArguments()), update, keyboard)
+
+			case update.Message.Command() == "unhide":
+				t.sendMessage(t.taskUseCase.UnhideSymbol(ownerID, update.Message.CommandArguments()), update,
+					keyboard)
+
+			case update.Message.Command() == "watch":
+				t.sendMessage(t.taskUseCase.WatchSymbol(ownerID, update.Message.CommandArguments()), update,
+					keyboard)
+
+			case update.Message.Command() == "unwatch":
+				t.sendMessage(t.taskUseCase.UnwatchSymbol(ownerID, update.Message.CommandArguments()), update,
+					keyboard)
+
+			case update.Message.Command() == "watchonly":
+				t.sendMessage(t.taskUseCase.SetWatchlistOnly(ownerID, update.Message.CommandArguments()), update,
+					keyboard)
+
+			case update.Message.Command() == "favorites":
+				if update.Message.CommandArguments() == "" {
+					t.sendMessage(t.taskUseCase.GetFavorites(ownerID), update, keyboard)
+					continue
+				}
+				t.sendMessage(t.taskUseCase.SetFavoriteThreshold(ownerID, update.Message.CommandArguments()), update,
+					keyboard)
+
+			case update.Message.Command() == "unfavorite":
+				t.sendMessage(t.taskUseCase.DeleteFavorite(ownerID, update.Message.CommandArguments()), update,
+					keyboard)
+
+			case update.Message.Command() == "lists":
+				t.sendMessage(t.taskUseCase.GetSymbolLists(ownerID), update, keyboard)
+
+			case text == "all" || strings.HasPrefix(text, "all "):
+				id, _, err := t.getSessionFromArgs(update.Message, strings.TrimPrefix(text, "all"))
+				if err != nil {
+					t.sendMessage(err.Error(), update, keyboard)
+					continue
+				}
+				transactions := t.taskUseCase.GetAllTransactions(id)
 				if len(transactions) == 0 {
 					t.sendMessage("Нет транзакций.", update, keyboard)
 					continue
 				}
-				t.sendAllButtons(transactions, update)
+				t.sendAllButtons(update.Message.Chat.ID, id, transactions)
+
+			case !update.Message.Chat.IsPrivate() && !update.Message.IsCommand():
+				continue
 
 			default:
 				t.sendMessage(`Такого действия ботом не предусмотрено или что-то было введено не верно`, update,
@@ -123,7 +249,7 @@ func (t TelegramController) Run(ctx context.Context) {
 			}
 
 		} else if update.CallbackQuery != nil {
-			t.sendInfo(update)
+			t.handleCallback(update)
 		}
 	}
 }
@@ -132,32 +258,249 @@ func (t TelegramController) sendMessage(text string, update tgbotapi.Update,
 	keyboard tgbotapi.ReplyKeyboardMarkup) {
 
 	msg := tgbotapi.NewMessage(update.Message.Chat.ID, text)
-	msg.ReplyMarkup = keyboard
+	if update.Message.Chat.IsPrivate() {
+		msg.ReplyMarkup = keyboard
+	} else {
+		msg.ReplyToMessageID = update.Message.MessageID
+	}
 	t.bot.Send(msg)
 }
 
-func (t TelegramController) handleRequest(update tgbotapi.Update) {
-	transactions := t.taskUseCase.HandleRequest(update.Message.Text, strconv.Itoa(int(update.Message.Chat.ID)))
-	if transactions == nil {
+func (t TelegramController) getOwnerFromMessage(message *tgbotapi.Message) (string, string) {
+	chatID := strconv.FormatInt(message.Chat.ID, 10)
+	if message.From == nil {
+		return chatID, chatID
+	}
+	return chatID, strconv.FormatInt(message.From.ID, 10)
+}
+
+func (t TelegramController) getSessionFromArgs(message *tgbotapi.Message, args string) (string, string,
+	error) {
+
+	chatID, ownerID := t.getOwnerFromMessage(message)
+	args = strings.TrimSpace(args)
+	first, rest, _ := strings.Cut(args, " ")
+
+	if owner, name, found := strings.Cut(first, ":"); found && entity.SessionNameRe.MatchString(name) {
+		if _, err := strconv.ParseInt(owner, 10, 64); err == nil {
+			if owner != ownerID && !t.isAdmin(message.Chat, message.From.ID) {
+				return "", "", errNotAdmin
+			}
+			return entity.NewSessionID(chatID, owner, name), strings.TrimSpace(rest), nil
+		}
+	}
+
+	names := t.sessions.names(entity.SessionPrefix(chatID, ownerID))
+	if slices.Contains(names, first) {
+		return entity.NewSessionID(chatID, ownerID, first), strings.TrimSpace(rest), nil
+	}
+	if len(names) == 1 {
+		return entity.NewSessionID(chatID, ownerID, names[0]), args, nil
+	}
+	return entity.NewSessionID(chatID, ownerID, entity.DefaultSessionName), args, nil
+}
+
+func (t TelegramController) sessionCommand(update tgbotapi.Update, keyboard tgbotapi.ReplyKeyboardMarkup,
+	handler func(id, requestIn string) string) {
+
+	id, args, err := t.getSessionFromArgs(update.Message, update.Message.CommandArguments())
+	if err != nil {
+		t.sendMessage(err.Error(), update, keyboard)
 		return
 	}
-	t.sendAllButtons(transactions, update)
+	t.sendMessage(handler(id, args), update, keyboard)
 }
 
-func (t TelegramController) sendAllButtons(transactions []entity.Transaction, update tgbotapi.Update) {
+func (t TelegramController) stopSessions(message *tgbotapi.Message, args string) string {
+	chatID, ownerID := t.getOwnerFromMessage(message)
+	args = strings.TrimSpace(args)
+
+	ids := []string{}
+	if strings.Contains(args, ":") {
+		id, _, err := t.getSessionFromArgs(message, args)
+		if err != nil {
+			return err.Error()
+		}
+		ids = append(ids, id)
+	} else {
+		for _, name := range t.sessions.names(entity.SessionPrefix(chatID, ownerID)) {
+			if args == "" || args == name {
+				ids = append(ids, entity.NewSessionID(chatID, ownerID, name))
+			}
+		}
+	}
+
+	names := []string{}
+	for _, id := range ids {
+		if t.stopSession(id) {
+			_, _, name := entity.ParseSessionID(id)
+			names = append(names, name)
+		}
+	}
+
+	if len(names) == 0 {
+		return "Нет активной сессии."
+	}
+	return fmt.Sprintf("Сессия %v отменена.", strings.Join(names, ", "))
+}
+
+func (t TelegramController) startSession(chatID int64, id, requestIn string) error {
+	select {
+	case t.semaphore <- struct{}{}:
+	default:
+		return errTooManySessions
+	}
+
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	edited := make(chan struct{}, 1)
+	if err := t.sessions.add(id, clientUpdate{cancelFunc: cancelFunc, time: time.Now(), edited: edited}); err != nil {
+		cancelFunc()
+		<-t.semaphore
+		return err
+	}
+
+	t.taskUseCase.CreateSession(id, requestIn)
+
+	go func(ctx context.Context) {
+		defer func() { <-t.semaphore }()
+		interval := time.Second * 120
+		t.handleRequest(chatID, id)
+
+		cancelStream := func() {}
+		defer func() { cancelStream() }()
+		subscribe := func() controller.SpotStream {
+			cancelStream()
+			var streamCtx context.Context
+			streamCtx, cancelStream = context.WithCancel(ctx)
+			return t.taskUseCase.SubscribeSession(streamCtx, id)
+		}
+		stream := subscribe()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case transactions := <-stream.Updates():
+				t.handleUpdate(chatID, id, transactions)
+			case <-edited:
+				stream = subscribe()
+			case <-time.After(time.Until(time.Now().Truncate(interval).Add(interval))):
+				if !stream.Streaming() {
+					t.handleRequest(chatID, id)
+				}
+			}
+		}
+	}(ctx)
+	return nil
+}
+
+func (t TelegramController) resubscribe(id string) {
+	session, exists := t.sessions.get(id)
+	if !exists {
+		return
+	}
+	select {
+	case session.edited <- struct{}{}:
+	default:
+	}
+}
+
+func (t TelegramController) stopSession(id string) bool {
+	clientUpdate, exists := t.sessions.remove(id)
+	if !exists {
+		return false
+	}
+	clientUpdate.cancelFunc()
+	t.keyboards.delete(id)
+	t.taskUseCase.DeleteSession(id)
+	return true
+}
+
+func (t TelegramController) handleRequest(chatID int64, id string) {
+	t.notify(chatID, id, t.taskUseCase.HandleRequest)
+}
+
+func (t TelegramController) handleUpdate(chatID int64, id string, spotTransactions []entity.Transaction) {
+	t.notify(chatID, id, func(id string) []entity.Transaction {
+		return t.taskUseCase.HandleUpdate(id, spotTransactions)
+	})
+}
+
+func (t TelegramController) notify(chatID int64, id string, handle func(id string) []entity.Transaction) {
+	summary, quietClosed := t.taskUseCase.GetQuietSummary(id)
+	if summary != "" {
+		t.send(tgbotapi.NewMessage(chatID, summary))
+	}
+	for _, transaction := range quietClosed {
+		t.sendClosed(chatID, id, transaction, false)
+	}
+
+	transactions := handle(id)
+	if len(transactions) != 0 {
+		t.sendAllButtons(chatID, id, transactions)
+	}
+
+	for _, alert := range t.taskUseCase.GetSpreadAlerts(id) {
+		msg := tgbotapi.NewMessage(chatID, alert)
+		msg.ParseMode = t.taskUseCase.GetCardParseMode()
+		t.send(msg)
+	}
+
+	closedTransactions, notify := t.taskUseCase.GetClosedTransactions(id)
+	for _, transaction := range closedTransactions {
+		t.sendClosed(chatID, id, transaction, notify)
+	}
+}
+
+func (t TelegramController) sendAllButtons(chatID int64, id string, transactions []entity.Transaction) {
+	_, _, name := entity.ParseSessionID(id)
 	buttons := []tgbotapi.InlineKeyboardButton{}
 
 	for _, transaction := range transactions {
-		textOnButton := fmt.Sprintf("🟢 %v: %.2f (%.2f%%)", transaction.Symbol, transaction.AmountCoin,
+		key := t.getKeyMsg(id, transaction)
+		if len(key) > maxCallbackData {
+			t.log.Error("deal key exceeds callback data limit", t.log.StringC("Key", key))
+			continue
+		}
+		textOnButton := fmt.Sprintf("🟢 %v · %v: %.2f (%.2f%%)", name, transaction.Symbol, transaction.AmountCoin,
 			transaction.Spread)
-		keyMsg := transaction.MarketFrom + "/" + transaction.MarketTo + "/" + transaction.Symbol
-		button := tgbotapi.NewInlineKeyboardButtonData(textOnButton, keyMsg)
-		buttons = append(buttons, button)
+		buttons = append(buttons, tgbotapi.NewInlineKeyboardButtonData(textOnButton, key))
+	}
+	if len(buttons) == 0 {
+		return
 	}
 	inlineKeyboard := t.createInlineKeyboard(buttons)
-	msg := tgbotapi.NewMessage(update.Message.Chat.ID, "Выберите подходящую Вам сделку:")
+	msg := tgbotapi.NewMessage(chatID, fmt.Sprintf("Сессия %v. Выберите подходящую Вам сделку:", name))
 	msg.ReplyMarkup = inlineKeyboard
-	t.bot.Send(msg)
+	sent, err := t.send(msg)
+	if err != nil {
+		t.log.Error("failed to send deals", t.log.ErrorC(err))
+		return
+	}
+	t.keyboards.add(id, sent.MessageID, inlineKeyboard)
+}
+
+func (t TelegramController) getKeyMsg(id string, transaction entity.Transaction) string {
+	_, ownerID, name := entity.ParseSessionID(id)
+	return ownerID + ":" + name + "/" + transaction.MarketFrom + "/" + transaction.MarketTo + "/" +
+		transaction.Symbol
+}
+
+func (t TelegramController) sendClosed(chatID int64, id string, transaction entity.Transaction, notify bool) {
+	_, _, name := entity.ParseSessionID(id)
+	textOnButton := fmt.Sprintf("⚫ %v · %v: закрыта", name, transaction.Symbol)
+	if posted, exists := t.keyboards.expire(id, t.getKeyMsg(id, transaction), textOnButton); exists {
+		t.bot.Send(tgbotapi.NewEditMessageReplyMarkup(chatID, posted.messageID, posted.markup))
+	}
+
+	if !notify {
+		return
+	}
+	if msgContent := t.taskUseCase.GetClosedText(id, transaction); msgContent != "" {
+		msg := tgbotapi.NewMessage(chatID, msgContent)
+		msg.ParseMode = t.taskUseCase.GetCardParseMode()
+		t.send(msg)
+	}
 }
 
 func (t TelegramController) createInlineKeyboard(buttons []tgbotapi.InlineKeyboardButton,
@@ -178,21 +521,144 @@ func (t TelegramController) createInlineKeyboard(buttons []tgbotapi.InlineKeyboa
 	return tgbotapi.NewInlineKeyboardMarkup(rows...)
 }
 
+func (t TelegramController) handleCallback(update tgbotapi.Update) {
+	callbackQuery := update.CallbackQuery
+
+	switch {
+	case strings.HasPrefix(callbackQuery.Data, hideCallbackPrefix):
+		t.hideSymbol(update)
+	case strings.HasPrefix(callbackQuery.Data, refreshCallbackPrefix):
+		t.refreshInfo(update)
+	case strings.HasPrefix(callbackQuery.Data, favoriteCallbackPrefix):
+		t.addFavorite(update)
+	case strings.HasPrefix(callbackQuery.Data, chartCallbackPrefix):
+		t.sendChart(update)
+	case strings.HasPrefix(callbackQuery.Data, expiredCallbackPrefix):
+		t.bot.Request(tgbotapi.NewCallback(callbackQuery.ID, "Сделка закрыта и больше не отслеживается"))
+	default:
+		t.sendInfo(update)
+	}
+}
+
+func (t TelegramController) hideSymbol(update tgbotapi.Update) {
+	callbackQuery := update.CallbackQuery
+	t.log.Info("User hid symbol", t.log.StringC("Data", callbackQuery.Data))
+	symbol := strings.TrimPrefix(callbackQuery.Data, hideCallbackPrefix)
+	msgContent := t.taskUseCase.HideSymbol(strconv.FormatInt(callbackQuery.From.ID, 10), symbol)
+	t.bot.Request(tgbotapi.NewCallback(callbackQuery.ID, msgContent))
+}
+
 func (t TelegramController) sendInfo(update tgbotapi.Update) {
 	callbackQuery := update.CallbackQuery
 	t.log.Info("User pressed button", t.log.StringC("Data", callbackQuery.Data))
-	marketFrom, marketTo, symbol := t.getKeyFromUpdate(callbackQuery)
-	msgContent := t.taskUseCase.GetInfoAboutTransactions(strconv.Itoa(int(callbackQuery.Message.Chat.ID)), marketFrom,
-		marketTo, symbol)
+	id, marketFrom, marketTo, symbol, ok := t.getSessionFromCallback(callbackQuery)
+	if !ok {
+		return
+	}
+	msgContent, chain := t.taskUseCase.GetInfoAboutTransactions(id, marketFrom, marketTo, symbol)
 	msg := tgbotapi.NewMessage(callbackQuery.Message.Chat.ID, msgContent)
-	msg.ParseMode = "Markdown"
-	t.bot.Send(msg)
+	msg.ParseMode = t.taskUseCase.GetCardParseMode()
+	msg.ReplyMarkup = t.getCardKeyboard(callbackQuery.Data, symbol, chain)
+	t.send(msg)
+}
+
+func (t TelegramController) getCardKeyboard(key, symbol, chain string) tgbotapi.InlineKeyboardMarkup {
+	row := tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData("🙈 Скрыть "+symbol, hideCallbackPrefix+symbol))
+	if refreshKey := refreshCallbackPrefix + key; len(refreshKey) <= maxCallbackData {
+		row = append(row, tgbotapi.NewInlineKeyboardButtonData("🔄 Обновить", refreshKey))
+	}
+	chartKey := chartCallbackPrefix + chain + "/" + key
+	if chain != "" && !strings.Contains(chain, "/") && len(chartKey) <= maxCallbackData {
+		row = append(row, tgbotapi.NewInlineKeyboardButtonData("📈 График", chartKey))
+	}
+	if favoriteKey := favoriteCallbackPrefix + key; len(favoriteKey) <= maxCallbackData {
+		row = append(row, tgbotapi.NewInlineKeyboardButtonData("⭐", favoriteKey))
+	}
+	return tgbotapi.NewInlineKeyboardMarkup(row)
+}
+
+func (t TelegramController) addFavorite(update tgbotapi.Update) {
+	callbackQuery := update.CallbackQuery
+	t.log.Info("User added favorite", t.log.StringC("Data", callbackQuery.Data))
+	callbackQuery.Data = strings.TrimPrefix(callbackQuery.Data, favoriteCallbackPrefix)
+	id, marketFrom, marketTo, symbol, ok := t.getSessionFromCallback(callbackQuery)
+	if !ok {
+		return
+	}
+
+	msgContent := t.taskUseCase.AddFavorite(strconv.FormatInt(callbackQuery.From.ID, 10), id, marketFrom,
+		marketTo, symbol)
+	t.bot.Request(tgbotapi.NewCallback(callbackQuery.ID, msgContent))
+}
+
+func (t TelegramController) refreshInfo(update tgbotapi.Update) {
+	callbackQuery := update.CallbackQuery
+	t.log.Info("User refreshed deal", t.log.StringC("Data", callbackQuery.Data))
+	key := strings.TrimPrefix(callbackQuery.Data, refreshCallbackPrefix)
+	callbackQuery.Data = key
+	id, marketFrom, marketTo, symbol, ok := t.getSessionFromCallback(callbackQuery)
+	if !ok {
+		return
+	}
+
+	msgContent, chain := t.taskUseCase.RefreshTransaction(id, marketFrom, marketTo, symbol)
+	msg := tgbotapi.NewEditMessageTextAndMarkup(callbackQuery.Message.Chat.ID, callbackQuery.Message.MessageID,
+		msgContent, t.getCardKeyboard(key, symbol, chain))
+	msg.ParseMode = t.taskUseCase.GetCardParseMode()
+	if _, err := t.bot.Send(msg); err != nil {
+		t.log.Error("failed to refresh deal", t.log.ErrorC(err))
+	}
+	t.bot.Request(tgbotapi.NewCallback(callbackQuery.ID, "Обновлено"))
+}
+
+func (t TelegramController) sendChart(update tgbotapi.Update) {
+	callbackQuery := update.CallbackQuery
+	t.log.Info("User requested chart", t.log.StringC("Data", callbackQuery.Data))
+	chain, key, _ := strings.Cut(strings.TrimPrefix(callbackQuery.Data, chartCallbackPrefix), "/")
+	callbackQuery.Data = key
+	id, marketFrom, marketTo, symbol, ok := t.getSessionFromCallback(callbackQuery)
+	if !ok {
+		return
+	}
+
+	image, caption := t.taskUseCase.GetSpreadChart(id, marketFrom, marketTo, symbol, chain)
+	if image == nil {
+		t.bot.Request(tgbotapi.NewCallback(callbackQuery.ID, caption))
+		return
+	}
+
+	t.bot.Request(tgbotapi.NewCallback(callbackQuery.ID, ""))
+	if err := t.sendPhoto(callbackQuery.Message.Chat.ID, caption, image); err != nil {
+		t.log.Error("failed to send chart", t.log.ErrorC(err))
+	}
+}
+
+func (t TelegramController) getSessionFromCallback(callbackQuery *tgbotapi.CallbackQuery) (string, string,
+	string, string, bool) {
+	session, marketFrom, marketTo, symbol, ok := t.getKeyFromUpdate(callbackQuery)
+	if !ok {
+		t.bot.Request(tgbotapi.NewCallback(callbackQuery.ID, "Не удалось разобрать сделку."))
+		return "", "", "", "", false
+	}
+	ownerID, name, found := strings.Cut(session, ":")
+	if !found {
+		ownerID, name = strconv.FormatInt(callbackQuery.From.ID, 10), session
+	}
+	id := entity.NewSessionID(strconv.FormatInt(callbackQuery.Message.Chat.ID, 10), ownerID, name)
+	return id, marketFrom, marketTo, symbol, true
 }
 
 func (t TelegramController) getKeyFromUpdate(update *tgbotapi.CallbackQuery) (string, string,
-	string) {
+	string, string, bool) {
 	parts := strings.Split(update.Data, "/")
-	return parts[0], parts[1], parts[2]
+	switch len(parts) {
+	case 3:
+		return entity.DefaultSessionName, parts[0], parts[1], parts[2], true
+	case 4:
+		return parts[0], parts[1], parts[2], parts[3], true
+	}
+	return "", "", "", "", false
 }
 
 func (t TelegramController) DeleteWebhook() error {
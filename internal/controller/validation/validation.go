@@ -0,0 +1,118 @@
+package validation
+
+import (
+	"context"
+	"crypto_pro/internal/controller"
+	"crypto_pro/internal/domain/entity"
+	"crypto_pro/internal/metrics"
+	"crypto_pro/pkg/logger"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+)
+
+const (
+	defaultSampleInterval = time.Minute
+	otherMarket           = "other"
+)
+
+type Server struct {
+	controller.Server
+	log      logger.Logger
+	interval time.Duration
+	mu       sync.Mutex
+	sampled  map[entity.Violation]time.Time
+}
+
+type spotStream struct {
+	controller.SpotStream
+	updates chan []entity.Transaction
+}
+
+func New(server controller.Server, log logger.Logger, interval time.Duration) controller.Server {
+	if interval <= 0 {
+		interval = defaultSampleInterval
+	}
+	return &Server{Server: server, log: log, interval: interval, sampled: map[entity.Violation]time.Time{}}
+}
+
+func (s *Server) GetSpotHandler(usdt, spreadMin, spreadMax float64) ([]entity.Transaction, error) {
+	transactions, err := s.Server.GetSpotHandler(usdt, spreadMin, spreadMax)
+	if err != nil {
+		return nil, err
+	}
+	return s.validate(transactions), nil
+}
+
+func (s *Server) GetSpotPair(usdt, spreadMin, spreadMax float64, symbol, marketFrom,
+	marketTo string) ([]entity.Transaction, error) {
+
+	transactions, err := s.Server.GetSpotPair(usdt, spreadMin, spreadMax, symbol, marketFrom, marketTo)
+	if err != nil {
+		return nil, err
+	}
+	return s.validate(transactions), nil
+}
+
+func (s *Server) SubscribeSpot(ctx context.Context, usdt, spreadMin, spreadMax float64) controller.SpotStream {
+	stream := &spotStream{SpotStream: s.Server.SubscribeSpot(ctx, usdt, spreadMin, spreadMax),
+		updates: make(chan []entity.Transaction)}
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case transactions := <-stream.SpotStream.Updates():
+				select {
+				case <-ctx.Done():
+					return
+				case stream.updates <- s.validate(transactions):
+				}
+			}
+		}
+	}()
+	return stream
+}
+
+func (s *spotStream) Updates() <-chan []entity.Transaction {
+	return s.updates
+}
+
+func (s *Server) validate(transactions []entity.Transaction) []entity.Transaction {
+	valid := []entity.Transaction{}
+	for _, transaction := range transactions {
+		violation := transaction.Violation()
+		if violation == nil {
+			valid = append(valid, transaction)
+			continue
+		}
+
+		label := marketLabel(violation.Market)
+		metrics.InvalidDeals.WithLabelValues(violation.Rule, label).Inc()
+		if s.sample(entity.Violation{Rule: violation.Rule, Market: label}) {
+			s.log.Info("spot transaction quarantined", s.log.StringC("Rule", violation.Rule),
+				s.log.StringC("Market", violation.Market), s.log.StringC("Transaction", fmt.Sprintf("%+v", transaction)))
+		}
+	}
+	return valid
+}
+
+func (s *Server) sample(violation entity.Violation) bool {
+	now := time.Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if now.Sub(s.sampled[violation]) < s.interval {
+		return false
+	}
+	s.sampled[violation] = now
+	return true
+}
+
+func marketLabel(market string) string {
+	if slices.Contains(entity.Markets, market) {
+		return market
+	}
+	return otherMarket
+}
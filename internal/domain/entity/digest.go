@@ -0,0 +1,113 @@
+package entity
+
+import (
+	"cmp"
+	"slices"
+	"time"
+)
+
+const (
+	DigestDaily  = "daily"
+	DigestWeekly = "weekly"
+)
+
+type Digest struct {
+	UserID  string
+	ChatID  string
+	Period  string
+	At      string
+	Weekday time.Weekday
+	SentAt  time.Time
+}
+
+type DigestReport struct {
+	UserID string
+	ChatID string
+	Text   string
+}
+
+type DigestStats struct {
+	Total       int
+	Best        []Transaction
+	Routes      []Count
+	Markets     []Count
+	AvgLifetime time.Duration
+}
+
+type Count struct {
+	Name  string
+	Value int
+}
+
+func (d Digest) Duration() time.Duration {
+	if d.Period == DigestWeekly {
+		return 7 * 24 * time.Hour
+	}
+	return 24 * time.Hour
+}
+
+func (d Digest) LastSlot(now time.Time, location *time.Location) (time.Time, bool) {
+	at, err := ParseClock(d.At)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	local := now.In(location)
+	slot := time.Date(local.Year(), local.Month(), local.Day(), at/60, at%60, 0, 0, location)
+	if slot.After(local) {
+		slot = slot.AddDate(0, 0, -1)
+	}
+	if d.Period == DigestWeekly {
+		slot = slot.AddDate(0, 0, -((int(slot.Weekday()) - int(d.Weekday) + 7) % 7))
+	}
+	return slot, true
+}
+
+func (d Digest) DueAt(now time.Time, location *time.Location) bool {
+	slot, ok := d.LastSlot(now, location)
+	return ok && d.SentAt.Before(slot)
+}
+
+func NewDigestStats(transactions []Transaction, limit int) DigestStats {
+	stats := DigestStats{Total: len(transactions)}
+
+	routes := map[string]int{}
+	markets := map[string]int{}
+	var lifetime time.Duration
+	closed := 0
+	for _, transaction := range transactions {
+		routes[transaction.Symbol+" "+transaction.MarketFrom+" → "+transaction.MarketTo]++
+		markets[transaction.MarketFrom]++
+		markets[transaction.MarketTo]++
+		if !transaction.ClosedAt.IsZero() && !transaction.CreatedAt.IsZero() {
+			lifetime += transaction.Lifetime()
+			closed++
+		}
+	}
+	if closed != 0 {
+		stats.AvgLifetime = lifetime / time.Duration(closed)
+	}
+
+	stats.Best = slices.Clone(transactions)
+	slices.SortFunc(stats.Best, func(a, b Transaction) int {
+		return cmp.Compare(b.PeakSpread, a.PeakSpread)
+	})
+	stats.Best = stats.Best[:min(limit, len(stats.Best))]
+	stats.Routes = topCounts(routes, limit)
+	stats.Markets = topCounts(markets, limit)
+	return stats
+}
+
+func topCounts(counts map[string]int, limit int) []Count {
+	response := []Count{}
+	for name, value := range counts {
+		response = append(response, Count{Name: name, Value: value})
+	}
+	slices.SortFunc(response, func(a, b Count) int {
+		if a.Value != b.Value {
+			return cmp.Compare(b.Value, a.Value)
+		}
+		return cmp.Compare(a.Name, b.Name)
+	})
+	return response[:min(limit, len(response))]
+}
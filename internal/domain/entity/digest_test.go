@@ -0,0 +1,136 @@
+package entity
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDigestLastSlot(t *testing.T) {
+	moscow, err := time.LoadLocation("Europe/Moscow")
+	if err != nil {
+		t.Fatal(err)
+	}
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		digest   Digest
+		now      time.Time
+		location *time.Location
+		want     time.Time
+		ok       bool
+	}{
+		{name: "daily after slot", digest: Digest{Period: DigestDaily, At: "09:00"},
+			now: time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC), location: time.UTC,
+			want: time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC), ok: true},
+		{name: "daily exactly at slot", digest: Digest{Period: DigestDaily, At: "09:00"},
+			now: time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC), location: time.UTC,
+			want: time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC), ok: true},
+		{name: "daily before slot", digest: Digest{Period: DigestDaily, At: "09:00"},
+			now: time.Date(2026, 10, 19, 8, 59, 0, 0, time.UTC), location: time.UTC,
+			want: time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC), ok: true},
+		{name: "daily local time zone", digest: Digest{Period: DigestDaily, At: "09:00"},
+			now: time.Date(2026, 10, 19, 6, 30, 0, 0, time.UTC), location: moscow,
+			want: time.Date(2026, 10, 19, 9, 0, 0, 0, moscow), ok: true},
+		{name: "weekly same day after slot",
+			digest: Digest{Period: DigestWeekly, Weekday: time.Monday, At: "09:00"},
+			now:    time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC), location: time.UTC,
+			want: time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC), ok: true},
+		{name: "weekly same day before slot",
+			digest: Digest{Period: DigestWeekly, Weekday: time.Monday, At: "09:00"},
+			now:    time.Date(2026, 10, 19, 8, 0, 0, 0, time.UTC), location: time.UTC,
+			want: time.Date(2026, 10, 12, 9, 0, 0, 0, time.UTC), ok: true},
+		{name: "weekly later in week", digest: Digest{Period: DigestWeekly, Weekday: time.Friday, At: "18:30"},
+			now: time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC), location: time.UTC,
+			want: time.Date(2026, 10, 16, 18, 30, 0, 0, time.UTC), ok: true},
+		{name: "daily across dst change", digest: Digest{Period: DigestDaily, At: "09:00"},
+			now: time.Date(2026, 10, 25, 9, 0, 0, 0, time.UTC), location: berlin,
+			want: time.Date(2026, 10, 25, 9, 0, 0, 0, berlin), ok: true},
+		{name: "invalid clock", digest: Digest{Period: DigestDaily, At: "9am"},
+			now: time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC), location: time.UTC},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, ok := test.digest.LastSlot(test.now, test.location)
+			if ok != test.ok || !got.Equal(test.want) {
+				t.Errorf("LastSlot(%v) = %v, %v, want %v, %v", test.now, got, ok, test.want, test.ok)
+			}
+		})
+	}
+}
+
+func TestDigestDueAt(t *testing.T) {
+	daily := Digest{Period: DigestDaily, At: "09:00"}
+	now := time.Date(2026, 10, 19, 9, 1, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		sentAt time.Time
+		at     string
+		want   bool
+	}{
+		{name: "sent before slot", sentAt: time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC), want: true},
+		{name: "never sent", want: true},
+		{name: "sent at slot", sentAt: time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)},
+		{name: "sent after slot", sentAt: time.Date(2026, 10, 19, 9, 0, 30, 0, time.UTC)},
+		{name: "invalid clock", sentAt: time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC), at: "25:00"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			digest := daily
+			digest.SentAt = test.sentAt
+			if test.at != "" {
+				digest.At = test.at
+			}
+			if got := digest.DueAt(now, time.UTC); got != test.want {
+				t.Errorf("DueAt(%v) with sent at %v = %v, want %v", now, test.sentAt, got, test.want)
+			}
+		})
+	}
+}
+
+func TestNewDigestStats(t *testing.T) {
+	created := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+	transactions := []Transaction{
+		{Symbol: "BTC", MarketFrom: "BYBIT", MarketTo: "MEXC", PeakSpread: 1.5, CreatedAt: created,
+			ClosedAt: created.Add(10 * time.Minute)},
+		{Symbol: "BTC", MarketFrom: "BYBIT", MarketTo: "MEXC", PeakSpread: 3, CreatedAt: created,
+			ClosedAt: created.Add(20 * time.Minute)},
+		{Symbol: "ETH", MarketFrom: "HTX", MarketTo: "MEXC", PeakSpread: 2, CreatedAt: created},
+		{Symbol: "TON", MarketFrom: "XT", MarketTo: "BYBIT", PeakSpread: 0.5},
+	}
+
+	stats := NewDigestStats(transactions, 2)
+	if stats.Total != 4 {
+		t.Errorf("Total = %v, want 4", stats.Total)
+	}
+	if len(stats.Best) != 2 || stats.Best[0].PeakSpread != 3 || stats.Best[1].PeakSpread != 2 {
+		t.Errorf("Best = %+v, want peak spreads 3 and 2", stats.Best)
+	}
+	routes := []Count{{Name: "BTC BYBIT → MEXC", Value: 2}, {Name: "ETH HTX → MEXC", Value: 1}}
+	if !reflect.DeepEqual(stats.Routes, routes) {
+		t.Errorf("Routes = %+v, want %+v", stats.Routes, routes)
+	}
+	markets := []Count{{Name: "BYBIT", Value: 3}, {Name: "MEXC", Value: 3}}
+	if !reflect.DeepEqual(stats.Markets, markets) {
+		t.Errorf("Markets = %+v, want %+v", stats.Markets, markets)
+	}
+	if stats.AvgLifetime != 15*time.Minute {
+		t.Errorf("AvgLifetime = %v, want 15m", stats.AvgLifetime)
+	}
+	if transactions[0].PeakSpread != 1.5 {
+		t.Error("NewDigestStats reordered the input")
+	}
+
+	empty := NewDigestStats(nil, 5)
+	if empty.Total != 0 || len(empty.Best) != 0 || len(empty.Routes) != 0 || len(empty.Markets) != 0 ||
+		empty.AvgLifetime != 0 {
+		t.Errorf("NewDigestStats(nil) = %+v", empty)
+	}
+}
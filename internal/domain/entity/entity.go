@@ -1,6 +1,57 @@
 package entity
 
-import "time"
+import (
+	"math"
+	"slices"
+	"strings"
+	"time"
+)
+
+var Markets = []string{"ASCENDEX", "BINGX", "BITGET", "BITMART", "BYBIT", "HTX", "KUKOIN", "MEXC", "XT"}
+
+var chainAliases = map[string]string{
+	"TRC20":         "TRC20",
+	"TRX":           "TRC20",
+	"TRON":          "TRC20",
+	"ERC20":         "ERC20",
+	"ETHEREUM":      "ERC20",
+	"BEP20":         "BEP20",
+	"BSC":           "BEP20",
+	"BNBSMART":      "BEP20",
+	"BNBSMARTCHAIN": "BEP20",
+	"BNBCHAIN":      "BEP20",
+	"SOL":           "SOL",
+	"SOLANA":        "SOL",
+	"SPL":           "SOL",
+	"ARBITRUM":      "ARBITRUM",
+	"ARB":           "ARBITRUM",
+	"ARBONE":        "ARBITRUM",
+	"ARBITRUMONE":   "ARBITRUM",
+	"OPTIMISM":      "OPTIMISM",
+	"OP":            "OPTIMISM",
+	"POLYGON":       "POLYGON",
+	"MATIC":         "POLYGON",
+	"AVAXC":         "AVAXC",
+	"AVAXCCHAIN":    "AVAXC",
+	"CCHAIN":        "AVAXC",
+	"TON":           "TON",
+	"TONCOIN":       "TON",
+	"BASE":          "BASE",
+}
+
+func NormalizeChain(chain string) string {
+	key := strings.Map(func(r rune) rune {
+		if r == ' ' || r == '-' || r == '_' || r == '(' || r == ')' {
+			return -1
+		}
+		return r
+	}, strings.ToUpper(chain))
+
+	if alias, exists := chainAliases[key]; exists {
+		return alias
+	}
+	return strings.ToUpper(strings.TrimSpace(chain))
+}
 
 type Transaction struct {
 	ID             string
@@ -19,7 +70,38 @@ type Transaction struct {
 	BidCost        float64
 	BidOrder       []Order
 	IsPosted       bool
+	PeakSpread     float64
+	CreatedAt      time.Time
 	UpdatedAt      time.Time
+	ClosedAt       time.Time
+}
+
+func (t Transaction) Lifetime() time.Duration {
+	return t.ClosedAt.Sub(t.CreatedAt)
+}
+
+type ExportFilter struct {
+	Format     string
+	Since      time.Time
+	Status     string
+	Symbol     string
+	MarketFrom string
+	MarketTo   string
+	Chain      string
+	MinSpread  float64
+}
+
+const (
+	ExportStatusAll    = "all"
+	ExportStatusOpen   = "open"
+	ExportStatusClosed = "closed"
+)
+
+type SpreadPoint struct {
+	Spread    float64
+	AskCost   float64
+	BidCost   float64
+	CreatedAt time.Time
 }
 
 type Order struct {
@@ -31,8 +113,135 @@ func (t *Transaction) SetID(id string) {
 	t.ID = id
 }
 
+func (t *Transaction) NormalizeChain() {
+	t.Chain = NormalizeChain(t.Chain)
+}
+
 type Session struct {
-	ID     string
-	USDT   float64
-	Spread float64
+	ID            string
+	USDT          float64
+	SpreadMin     float64
+	SpreadMax     float64
+	BuyMarkets    []string
+	SellMarkets   []string
+	IncludeChains []string
+	ExcludeChains []string
+	Guards        Guards
+	Alerts        Alerts
+	NotifyClosed  bool
+	QuietPending  bool
+}
+
+type Alerts struct {
+	SpreadAbs float64
+	SpreadRel float64
+}
+
+func (a Alerts) Enabled() bool {
+	return a.SpreadAbs > 0 || a.SpreadRel > 0
+}
+
+func (a Alerts) Triggered(change SpreadChange) bool {
+	delta := math.Abs(change.Current.Spread - change.Previous.Spread)
+	if a.SpreadAbs > 0 && delta >= a.SpreadAbs {
+		return true
+	}
+	if a.SpreadRel > 0 && change.Previous.Spread != 0 &&
+		delta/math.Abs(change.Previous.Spread)*100 >= a.SpreadRel {
+		return true
+	}
+	return false
+}
+
+type SpreadChange struct {
+	Previous Transaction
+	Current  Transaction
+}
+
+type Guards struct {
+	MaxFeePercent    float64
+	CheckWithdrawMax bool
+	MinOrders        int
+	MinDepthUSDT     float64
+}
+
+const (
+	RejectFee         = "fee"
+	RejectWithdrawMax = "withdraw_max"
+	RejectOrders      = "orders"
+	RejectDepth       = "depth"
+)
+
+func (g Guards) RejectReason(t Transaction) string {
+	if g.MaxFeePercent > 0 && (t.AmountCoin <= 0 || t.WithDrawFee/t.AmountCoin*100 > g.MaxFeePercent) {
+		return RejectFee
+	}
+	if g.CheckWithdrawMax && t.AmountCoin > t.WithdrawMax {
+		return RejectWithdrawMax
+	}
+	if g.MinOrders > 0 && (t.AmountAskOrder < float64(g.MinOrders) || t.AmountBidOrder < float64(g.MinOrders)) {
+		return RejectOrders
+	}
+	if g.MinDepthUSDT > 0 && (depth(t.AskOrder) < g.MinDepthUSDT || depth(t.BidOrder) < g.MinDepthUSDT) {
+		return RejectDepth
+	}
+	return ""
+}
+
+func (g Guards) Valid() bool {
+	return g.MaxFeePercent >= 0 && !math.IsInf(g.MaxFeePercent, 0) && g.MinOrders >= 0 &&
+		g.MinDepthUSDT >= 0 && !math.IsInf(g.MinDepthUSDT, 0)
+}
+
+func depth(orders []Order) float64 {
+	total := 0.0
+	for _, order := range orders {
+		total += order.Price * order.Qty
+	}
+	return total
+}
+
+func (s Session) AllowsMarkets(marketFrom, marketTo string) bool {
+	if len(s.BuyMarkets) != 0 && !slices.Contains(s.BuyMarkets, marketFrom) {
+		return false
+	}
+	if len(s.SellMarkets) != 0 && !slices.Contains(s.SellMarkets, marketTo) {
+		return false
+	}
+	return true
+}
+
+func (s Session) AllowsChain(chain string) bool {
+	if slices.Contains(s.ExcludeChains, chain) {
+		return false
+	}
+	if len(s.IncludeChains) != 0 && !slices.Contains(s.IncludeChains, chain) {
+		return false
+	}
+	return true
+}
+
+const (
+	UserBlacklist = "blacklist"
+	UserWatchlist = "watchlist"
+)
+
+type User struct {
+	ID            string
+	Blacklist     []string
+	Watchlist     []string
+	WatchlistOnly bool
+	TimeZone      string
+	QuietFrom     string
+	QuietTo       string
+}
+
+func (u User) AllowsSymbol(symbol string) bool {
+	if slices.Contains(u.Blacklist, symbol) {
+		return false
+	}
+	if u.WatchlistOnly && !slices.Contains(u.Watchlist, symbol) {
+		return false
+	}
+	return true
 }
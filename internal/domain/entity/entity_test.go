@@ -0,0 +1,81 @@
+package entity
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNormalizeChain(t *testing.T) {
+	tests := []struct {
+		chain string
+		want  string
+	}{
+		{chain: "TRC20", want: "TRC20"},
+		{chain: "trc-20", want: "TRC20"},
+		{chain: "Tron", want: "TRC20"},
+		{chain: "ERC20", want: "ERC20"},
+		{chain: "Ethereum", want: "ERC20"},
+		{chain: "ETH", want: "ETH"},
+		{chain: "BEP-20", want: "BEP20"},
+		{chain: "BNB Smart Chain", want: "BEP20"},
+		{chain: "bnb_smart", want: "BEP20"},
+		{chain: "BNB", want: "BNB"},
+		{chain: "BEP2", want: "BEP2"},
+		{chain: "Arbitrum One", want: "ARBITRUM"},
+		{chain: "AVAX-C", want: "AVAXC"},
+		{chain: " sol ", want: "SOL"},
+		{chain: "Unknown Net", want: "UNKNOWN NET"},
+	}
+
+	for _, test := range tests {
+		if got := NormalizeChain(test.chain); got != test.want {
+			t.Errorf("NormalizeChain(%q) = %q, want %q", test.chain, got, test.want)
+		}
+	}
+}
+
+func TestGuardsDepth(t *testing.T) {
+	transaction := Transaction{AmountCoin: 1, AskCost: 1000, BidCost: 1010,
+		AskOrder: []Order{{Price: 100, Qty: 5}, {Price: 101, Qty: 10}},
+		BidOrder: []Order{{Price: 102, Qty: 2}, {Price: 101, Qty: 3}}}
+
+	tests := []struct {
+		minDepth float64
+		want     string
+	}{
+		{minDepth: 0},
+		{minDepth: 500},
+		{minDepth: 507},
+		{minDepth: 508, want: RejectDepth},
+		{minDepth: 1000, want: RejectDepth},
+	}
+
+	for _, test := range tests {
+		if got := (Guards{MinDepthUSDT: test.minDepth}).RejectReason(transaction); got != test.want {
+			t.Errorf("depth %v: RejectReason = %q, want %q", test.minDepth, got, test.want)
+		}
+	}
+}
+
+func TestGuardsValid(t *testing.T) {
+	tests := []struct {
+		guards Guards
+		want   bool
+	}{
+		{guards: Guards{}, want: true},
+		{guards: Guards{MaxFeePercent: 2, CheckWithdrawMax: true, MinOrders: 2, MinDepthUSDT: 50}, want: true},
+		{guards: Guards{MaxFeePercent: -1}},
+		{guards: Guards{MaxFeePercent: math.NaN()}},
+		{guards: Guards{MaxFeePercent: math.Inf(1)}},
+		{guards: Guards{MinOrders: -1}},
+		{guards: Guards{MinDepthUSDT: -50}},
+		{guards: Guards{MinDepthUSDT: math.NaN()}},
+		{guards: Guards{MinDepthUSDT: math.Inf(1)}},
+	}
+
+	for _, test := range tests {
+		if got := test.guards.Valid(); got != test.want {
+			t.Errorf("%+v.Valid() = %v, want %v", test.guards, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+)
+
+type Favorite struct {
+	ID         int64
+	UserID     string
+	ChatID     string
+	Symbol     string
+	Chain      string
+	MarketFrom string
+	MarketTo   string
+	USDT       float64
+	Threshold  float64
+	Spread     float64
+	IsAbove    bool
+	CheckedAt  time.Time
+}
+
+type FavoriteAlert struct {
+	ChatID string
+	Text   string
+}
+
+func (f Favorite) Route() string {
+	return fmt.Sprintf("%v %v → %v (%v)", f.Symbol, f.MarketFrom, f.MarketTo, f.Chain)
+}
+
+func (f Favorite) Matches(transaction Transaction) bool {
+	return f.Symbol == transaction.Symbol && f.Chain == transaction.Chain &&
+		f.MarketFrom == transaction.MarketFrom && f.MarketTo == transaction.MarketTo
+}
@@ -0,0 +1,94 @@
+package entity
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+const DefaultTimeZone = "UTC"
+
+var Weekdays = map[string]time.Weekday{
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+	"sun": time.Sunday,
+}
+
+type Schedule struct {
+	ID        int64
+	SessionID string
+	Request   string
+	Weekdays  []time.Weekday
+	Start     string
+	Stop      string
+	Active    bool
+}
+
+func (s Schedule) ActiveAt(now time.Time, location *time.Location) bool {
+	local := now.In(location)
+	minutes := local.Hour()*60 + local.Minute()
+
+	start, err := ParseClock(s.Start)
+	if err != nil {
+		return false
+	}
+	stop, err := ParseClock(s.Stop)
+	if err != nil {
+		return false
+	}
+
+	if start <= stop {
+		return slices.Contains(s.Weekdays, local.Weekday()) && minutes >= start && minutes < stop
+	}
+
+	if minutes >= start {
+		return slices.Contains(s.Weekdays, local.Weekday())
+	}
+	return minutes < stop && slices.Contains(s.Weekdays, local.AddDate(0, 0, -1).Weekday())
+}
+
+func (u User) Location() *time.Location {
+	timeZone := u.TimeZone
+	if timeZone == "" {
+		timeZone = DefaultTimeZone
+	}
+	location, err := time.LoadLocation(timeZone)
+	if err != nil {
+		return time.UTC
+	}
+	return location
+}
+
+func (u User) IsQuiet(now time.Time) bool {
+	if u.QuietFrom == "" || u.QuietTo == "" {
+		return false
+	}
+
+	from, err := ParseClock(u.QuietFrom)
+	if err != nil {
+		return false
+	}
+	to, err := ParseClock(u.QuietTo)
+	if err != nil {
+		return false
+	}
+
+	local := now.In(u.Location())
+	minutes := local.Hour()*60 + local.Minute()
+	if from <= to {
+		return minutes >= from && minutes < to
+	}
+	return minutes >= from || minutes < to
+}
+
+func ParseClock(clock string) (int, error) {
+	parsed, err := time.Parse("15:04", clock)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q: %w", clock, err)
+	}
+	return parsed.Hour()*60 + parsed.Minute(), nil
+}
@@ -0,0 +1,104 @@
+package entity
+
+import (
+	"testing"
+	"time"
+)
+
+func TestScheduleActiveAt(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	weekdays := []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday}
+
+	tests := []struct {
+		name     string
+		schedule Schedule
+		now      time.Time
+		location *time.Location
+		want     bool
+	}{
+		{name: "inside day window", schedule: Schedule{Weekdays: weekdays, Start: "09:00", Stop: "18:00"},
+			now: time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC), location: time.UTC, want: true},
+		{name: "start inclusive", schedule: Schedule{Weekdays: weekdays, Start: "09:00", Stop: "18:00"},
+			now: time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC), location: time.UTC, want: true},
+		{name: "stop exclusive", schedule: Schedule{Weekdays: weekdays, Start: "09:00", Stop: "18:00"},
+			now: time.Date(2026, 10, 19, 18, 0, 0, 0, time.UTC), location: time.UTC},
+		{name: "weekend", schedule: Schedule{Weekdays: weekdays, Start: "09:00", Stop: "18:00"},
+			now: time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC), location: time.UTC},
+		{name: "local time zone", schedule: Schedule{Weekdays: weekdays, Start: "09:00", Stop: "18:00"},
+			now: time.Date(2026, 10, 19, 7, 30, 0, 0, time.UTC), location: berlin, want: true},
+		{name: "overnight before midnight",
+			schedule: Schedule{Weekdays: []time.Weekday{time.Friday}, Start: "22:00", Stop: "06:00"},
+			now:      time.Date(2026, 10, 23, 23, 0, 0, 0, time.UTC), location: time.UTC, want: true},
+		{name: "overnight after midnight belongs to previous day",
+			schedule: Schedule{Weekdays: []time.Weekday{time.Friday}, Start: "22:00", Stop: "06:00"},
+			now:      time.Date(2026, 10, 24, 5, 0, 0, 0, time.UTC), location: time.UTC, want: true},
+		{name: "overnight after midnight of unscheduled day",
+			schedule: Schedule{Weekdays: []time.Weekday{time.Friday}, Start: "22:00", Stop: "06:00"},
+			now:      time.Date(2026, 10, 23, 5, 0, 0, 0, time.UTC), location: time.UTC},
+		{name: "overnight after stop",
+			schedule: Schedule{Weekdays: []time.Weekday{time.Friday}, Start: "22:00", Stop: "06:00"},
+			now:      time.Date(2026, 10, 24, 6, 0, 0, 0, time.UTC), location: time.UTC},
+		{name: "dst spring forward skips window",
+			schedule: Schedule{Weekdays: []time.Weekday{time.Sunday}, Start: "02:00", Stop: "03:00"},
+			now:      time.Date(2026, 3, 29, 1, 0, 0, 0, time.UTC), location: berlin},
+		{name: "dst fall back first hour",
+			schedule: Schedule{Weekdays: []time.Weekday{time.Sunday}, Start: "02:00", Stop: "03:00"},
+			now:      time.Date(2026, 10, 25, 0, 30, 0, 0, time.UTC), location: berlin, want: true},
+		{name: "dst fall back repeated hour",
+			schedule: Schedule{Weekdays: []time.Weekday{time.Sunday}, Start: "02:00", Stop: "03:00"},
+			now:      time.Date(2026, 10, 25, 1, 30, 0, 0, time.UTC), location: berlin, want: true},
+		{name: "invalid clock", schedule: Schedule{Weekdays: weekdays, Start: "9am", Stop: "18:00"},
+			now: time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC), location: time.UTC},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := test.schedule.ActiveAt(test.now, test.location); got != test.want {
+				t.Errorf("ActiveAt(%v) = %v, want %v", test.now.In(test.location), got, test.want)
+			}
+		})
+	}
+}
+
+func TestUserIsQuiet(t *testing.T) {
+	tests := []struct {
+		name string
+		user User
+		now  time.Time
+		want bool
+	}{
+		{name: "no window", user: User{}, now: time.Date(2026, 10, 19, 3, 0, 0, 0, time.UTC)},
+		{name: "day window", user: User{QuietFrom: "13:00", QuietTo: "15:00"},
+			now: time.Date(2026, 10, 19, 14, 0, 0, 0, time.UTC), want: true},
+		{name: "day window end exclusive", user: User{QuietFrom: "13:00", QuietTo: "15:00"},
+			now: time.Date(2026, 10, 19, 15, 0, 0, 0, time.UTC)},
+		{name: "overnight before midnight", user: User{QuietFrom: "23:00", QuietTo: "08:00"},
+			now: time.Date(2026, 10, 19, 23, 30, 0, 0, time.UTC), want: true},
+		{name: "overnight after midnight", user: User{QuietFrom: "23:00", QuietTo: "08:00"},
+			now: time.Date(2026, 10, 19, 7, 59, 0, 0, time.UTC), want: true},
+		{name: "overnight outside", user: User{QuietFrom: "23:00", QuietTo: "08:00"},
+			now: time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)},
+		{name: "local time zone", user: User{TimeZone: "Europe/Moscow", QuietFrom: "23:00", QuietTo: "08:00"},
+			now: time.Date(2026, 10, 19, 21, 0, 0, 0, time.UTC), want: true},
+		{name: "before dst spring forward",
+			user: User{TimeZone: "America/New_York", QuietFrom: "23:00", QuietTo: "08:00"},
+			now:  time.Date(2026, 3, 7, 12, 30, 0, 0, time.UTC), want: true},
+		{name: "after dst spring forward",
+			user: User{TimeZone: "America/New_York", QuietFrom: "23:00", QuietTo: "08:00"},
+			now:  time.Date(2026, 3, 8, 12, 30, 0, 0, time.UTC)},
+		{name: "unknown time zone falls back to utc",
+			user: User{TimeZone: "Mars/Olympus", QuietFrom: "23:00", QuietTo: "08:00"},
+			now:  time.Date(2026, 10, 19, 23, 30, 0, 0, time.UTC), want: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := test.user.IsQuiet(test.now); got != test.want {
+				t.Errorf("IsQuiet(%v) = %v, want %v", test.now, got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package entity
+
+import (
+	"regexp"
+	"strings"
+)
+
+const DefaultSessionName = "main"
+
+var SessionNameRe = regexp.MustCompile(`^[A-Za-z0-9_-]{1,16}$`)
+
+func NewSessionID(chatID, ownerID, name string) string {
+	return chatID + ":" + ownerID + ":" + name
+}
+
+func SessionPrefix(chatID, ownerID string) string {
+	return chatID + ":" + ownerID + ":"
+}
+
+func ParseSessionID(id string) (string, string, string) {
+	parts := strings.SplitN(id, ":", 3)
+	if len(parts) != 3 {
+		return id, id, DefaultSessionName
+	}
+	return parts[0], parts[1], parts[2]
+}
+
+func (s Session) Name() string {
+	_, _, name := ParseSessionID(s.ID)
+	return name
+}
+
+func (s Session) OwnerID() string {
+	_, ownerID, _ := ParseSessionID(s.ID)
+	return ownerID
+}
+
+type SessionFilters struct {
+	BuyMarkets    []string
+	SellMarkets   []string
+	IncludeChains []string
+	ExcludeChains []string
+	Guards        Guards
+}
+
+func (s Session) Filters() SessionFilters {
+	return SessionFilters{BuyMarkets: s.BuyMarkets, SellMarkets: s.SellMarkets, IncludeChains: s.IncludeChains,
+		ExcludeChains: s.ExcludeChains, Guards: s.Guards}
+}
@@ -0,0 +1,77 @@
+package entity
+
+import (
+	"cmp"
+	"math"
+)
+
+const (
+	InvalidNumber     = "nan"
+	InvalidMarket     = "market"
+	InvalidSameMarket = "same_market"
+	InvalidSymbol     = "symbol"
+	InvalidSpread     = "spread"
+	InvalidAmount     = "amount"
+	InvalidAskOrders  = "ask_orders"
+	InvalidBidOrders  = "bid_orders"
+	InvalidAskPrice   = "ask_price"
+	InvalidBidPrice   = "bid_price"
+)
+
+type Violation struct {
+	Rule   string
+	Market string
+}
+
+func (t Transaction) Violation() *Violation {
+	if !isFinite(t.Spread, t.WithDrawFee, t.WithdrawMax, t.AmountCoin, t.AmountAskOrder, t.AskCost,
+		t.AmountBidOrder, t.BidCost) {
+		return &Violation{Rule: InvalidNumber, Market: t.MarketFrom}
+	}
+	if t.MarketFrom == "" || t.MarketTo == "" {
+		return &Violation{Rule: InvalidMarket, Market: cmp.Or(t.MarketFrom, t.MarketTo)}
+	}
+	if t.MarketFrom == t.MarketTo {
+		return &Violation{Rule: InvalidSameMarket, Market: t.MarketFrom}
+	}
+	if t.Symbol == "" {
+		return &Violation{Rule: InvalidSymbol, Market: t.MarketFrom}
+	}
+	if t.Spread < 0 {
+		return &Violation{Rule: InvalidSpread, Market: t.MarketFrom}
+	}
+	if t.AmountCoin <= 0 || t.AskCost < 0 || t.BidCost < 0 || t.WithDrawFee < 0 || t.WithdrawMax < 0 {
+		return &Violation{Rule: InvalidAmount, Market: t.MarketFrom}
+	}
+	if len(t.AskOrder) == 0 {
+		return &Violation{Rule: InvalidAskOrders, Market: t.MarketFrom}
+	}
+	if len(t.BidOrder) == 0 {
+		return &Violation{Rule: InvalidBidOrders, Market: t.MarketTo}
+	}
+	if !validOrders(t.AskOrder) {
+		return &Violation{Rule: InvalidAskPrice, Market: t.MarketFrom}
+	}
+	if !validOrders(t.BidOrder) {
+		return &Violation{Rule: InvalidBidPrice, Market: t.MarketTo}
+	}
+	return nil
+}
+
+func validOrders(orders []Order) bool {
+	for _, order := range orders {
+		if !isFinite(order.Price, order.Qty) || order.Price <= 0 || order.Qty <= 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func isFinite(values ...float64) bool {
+	for _, value := range values {
+		if math.IsNaN(value) || math.IsInf(value, 0) {
+			return false
+		}
+	}
+	return true
+}
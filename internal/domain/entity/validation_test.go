@@ -0,0 +1,72 @@
+package entity
+
+import (
+	"math"
+	"testing"
+)
+
+func validTransaction() Transaction {
+	return Transaction{Symbol: "BTC", Chain: "BTC", MarketFrom: "BYBIT", MarketTo: "MEXC", Spread: 1.5,
+		WithDrawFee: 0.0001, WithdrawMax: 10, AmountCoin: 0.02, AmountAskOrder: 1, AskCost: 1000,
+		AskOrder: []Order{{Price: 50000, Qty: 0.02}}, AmountBidOrder: 1, BidCost: 1015,
+		BidOrder: []Order{{Price: 50750, Qty: 0.02}}}
+}
+
+func TestViolation(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(t *Transaction)
+		want   *Violation
+	}{
+		{name: "valid", modify: func(t *Transaction) {}},
+		{name: "zero spread", modify: func(t *Transaction) { t.Spread = 0 }},
+		{name: "zero fee", modify: func(t *Transaction) { t.WithDrawFee, t.WithdrawMax = 0, 0 }},
+		{name: "nan spread", modify: func(t *Transaction) { t.Spread = math.NaN() },
+			want: &Violation{Rule: InvalidNumber, Market: "BYBIT"}},
+		{name: "inf cost", modify: func(t *Transaction) { t.BidCost = math.Inf(1) },
+			want: &Violation{Rule: InvalidNumber, Market: "BYBIT"}},
+		{name: "no market from", modify: func(t *Transaction) { t.MarketFrom = "" },
+			want: &Violation{Rule: InvalidMarket, Market: "MEXC"}},
+		{name: "no markets", modify: func(t *Transaction) { t.MarketFrom, t.MarketTo = "", "" },
+			want: &Violation{Rule: InvalidMarket}},
+		{name: "same market", modify: func(t *Transaction) { t.MarketTo = "BYBIT" },
+			want: &Violation{Rule: InvalidSameMarket, Market: "BYBIT"}},
+		{name: "no symbol", modify: func(t *Transaction) { t.Symbol = "" },
+			want: &Violation{Rule: InvalidSymbol, Market: "BYBIT"}},
+		{name: "negative spread", modify: func(t *Transaction) { t.Spread = -0.1 },
+			want: &Violation{Rule: InvalidSpread, Market: "BYBIT"}},
+		{name: "zero amount", modify: func(t *Transaction) { t.AmountCoin = 0 },
+			want: &Violation{Rule: InvalidAmount, Market: "BYBIT"}},
+		{name: "negative ask cost", modify: func(t *Transaction) { t.AskCost = -1 },
+			want: &Violation{Rule: InvalidAmount, Market: "BYBIT"}},
+		{name: "negative withdraw max", modify: func(t *Transaction) { t.WithdrawMax = -1 },
+			want: &Violation{Rule: InvalidAmount, Market: "BYBIT"}},
+		{name: "no ask orders", modify: func(t *Transaction) { t.AskOrder = nil },
+			want: &Violation{Rule: InvalidAskOrders, Market: "BYBIT"}},
+		{name: "no bid orders", modify: func(t *Transaction) { t.BidOrder = []Order{} },
+			want: &Violation{Rule: InvalidBidOrders, Market: "MEXC"}},
+		{name: "zero ask price", modify: func(t *Transaction) { t.AskOrder[0].Price = 0 },
+			want: &Violation{Rule: InvalidAskPrice, Market: "BYBIT"}},
+		{name: "nan ask qty", modify: func(t *Transaction) { t.AskOrder[0].Qty = math.NaN() },
+			want: &Violation{Rule: InvalidAskPrice, Market: "BYBIT"}},
+		{name: "negative bid qty",
+			modify: func(t *Transaction) { t.BidOrder = append(t.BidOrder, Order{Price: 50700, Qty: -1}) },
+			want:   &Violation{Rule: InvalidBidPrice, Market: "MEXC"}},
+		{name: "first rule wins", modify: func(t *Transaction) { t.Symbol, t.Spread = "", -1 },
+			want: &Violation{Rule: InvalidSymbol, Market: "BYBIT"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			transaction := validTransaction()
+			test.modify(&transaction)
+
+			got := transaction.Violation()
+			switch {
+			case got == nil && test.want == nil:
+			case got == nil || test.want == nil || *got != *test.want:
+				t.Errorf("Violation() = %+v, want %+v", got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,10 @@
+package usecase
+
+import (
+	"errors"
+)
+
+var (
+	ErrNotFound     = errors.New("not found")
+	ErrInvalidInput = errors.New("invalid input")
+)
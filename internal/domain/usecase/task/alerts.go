@@ -0,0 +1,149 @@
+package task
+
+import (
+	"crypto_pro/internal/domain/entity"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+func (b TaskUseCase) SetAlerts(id, requestIn string) string {
+	session := b.dbAdapter.SelectSession(id)
+	if session.ID == "" {
+		return "Нет активной сессии."
+	}
+
+	if strings.TrimSpace(requestIn) == "" {
+		return b.formatAlerts(session.Alerts)
+	}
+
+	alerts, err := b.getAlertsIn(requestIn, session.Alerts)
+	if err != nil {
+		return err.Error()
+	}
+
+	if err := b.dbAdapter.UpdateSessionAlerts(id, alerts); err != nil {
+		return "Не удалось сохранить оповещения."
+	}
+	return b.formatAlerts(alerts)
+}
+
+func (b TaskUseCase) getAlertsIn(input string, alerts entity.Alerts) (entity.Alerts, error) {
+	for _, field := range strings.Fields(strings.ToLower(input)) {
+		if field == "off" {
+			alerts = entity.Alerts{}
+			continue
+		}
+
+		key, value, found := strings.Cut(field, "=")
+		if !found {
+			return alerts, fmt.Errorf("Не понял параметр %s. Пример: /alerts abs=0.5 rel=50", field)
+		}
+
+		var err error
+		switch key {
+		case "abs":
+			alerts.SpreadAbs, err = strconv.ParseFloat(value, 64)
+		case "rel":
+			alerts.SpreadRel, err = strconv.ParseFloat(value, 64)
+		default:
+			return alerts, fmt.Errorf("Неизвестный параметр %s. Доступны: abs, rel", key)
+		}
+		if err != nil {
+			return alerts, fmt.Errorf("Неверное значение %s для %s", value, key)
+		}
+	}
+	return alerts, nil
+}
+
+func (b TaskUseCase) formatAlerts(alerts entity.Alerts) string {
+	if !alerts.Enabled() {
+		return "🔔 Оповещения об изменении спреда: выключены"
+	}
+
+	msgContent := "🔔 Оповещения об изменении спреда \n"
+	if alerts.SpreadAbs > 0 {
+		msgContent += fmt.Sprintf("Изменение на: %v п.п. \n", alerts.SpreadAbs)
+	}
+	if alerts.SpreadRel > 0 {
+		msgContent += fmt.Sprintf("Изменение на: %v %% от прежнего спреда \n", alerts.SpreadRel)
+	}
+	return strings.TrimSuffix(msgContent, " \n")
+}
+
+func (b TaskUseCase) GetSpreadAlerts(id string) []string {
+	session := b.dbAdapter.SelectSession(id)
+	if !session.Alerts.Enabled() {
+		return nil
+	}
+	user, err := b.dbAdapter.SelectUser(session.OwnerID())
+	if err != nil || user.IsQuiet(time.Now()) {
+		return nil
+	}
+
+	messages := []string{}
+	for _, change := range b.dbAdapter.SelectPostedTransactions(id) {
+		if !session.Alerts.Triggered(change) {
+			continue
+		}
+		msgContent, err := b.cards.Alert(change)
+		if err != nil {
+			b.log.Error("failed to render spread alert", b.log.ErrorC(err))
+			continue
+		}
+		if err := b.dbAdapter.UpdatePostedSnapshot(change.Current); err != nil {
+			continue
+		}
+		messages = append(messages, msgContent)
+	}
+	return messages
+}
+
+func (b TaskUseCase) SetNotifyClosed(id, requestIn string) string {
+	session := b.dbAdapter.SelectSession(id)
+	if session.ID == "" {
+		return "Нет активной сессии."
+	}
+
+	var notifyClosed bool
+	switch strings.ToLower(strings.TrimSpace(requestIn)) {
+	case "on":
+		notifyClosed = true
+	case "off":
+		notifyClosed = false
+	default:
+		return "Используйте /closed on или /closed off"
+	}
+
+	if err := b.dbAdapter.UpdateSessionNotifyClosed(id, notifyClosed); err != nil {
+		return "Не удалось сохранить уведомления о закрытии сделок."
+	}
+	return b.formatNotifyClosed(notifyClosed)
+}
+
+func (b TaskUseCase) formatNotifyClosed(notifyClosed bool) string {
+	if notifyClosed {
+		return "🔕 Уведомления о закрытии сделок: включены"
+	}
+	return "🔕 Уведомления о закрытии сделок: выключены"
+}
+
+func (b TaskUseCase) GetClosedText(id string, transaction entity.Transaction) string {
+	_, _, name := entity.ParseSessionID(id)
+	msgContent, err := b.cards.Closed(name, transaction)
+	if err != nil {
+		b.log.Error("failed to render closed deal", b.log.ErrorC(err))
+		return ""
+	}
+	return msgContent
+}
+
+func (b TaskUseCase) GetClosedTransactions(id string) ([]entity.Transaction, bool) {
+	session := b.dbAdapter.SelectSession(id)
+	user, err := b.dbAdapter.SelectUser(session.OwnerID())
+	if err != nil || user.IsQuiet(time.Now()) {
+		return nil, false
+	}
+	return b.dbAdapter.SelectClosedTransactions(id), session.NotifyClosed
+}
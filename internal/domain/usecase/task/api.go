@@ -0,0 +1,89 @@
+package task
+
+import (
+	"crypto_pro/internal/domain/entity"
+	"crypto_pro/internal/domain/usecase"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+const maxSpotUSDT = 1_000_000
+
+func (b TaskUseCase) GetSpot(usdt, spreadMin, spreadMax float64) ([]entity.Transaction, error) {
+	if !(usdt > 0 && usdt <= maxSpotUSDT) {
+		return nil, fmt.Errorf("%w: usdt must be in (0, %v]", usecase.ErrInvalidInput, maxSpotUSDT)
+	}
+	if !(spreadMin >= 0 && spreadMin <= spreadMax && spreadMax <= favoriteSpreadMax) {
+		return nil, fmt.Errorf("%w: spread must satisfy 0 <= spread_min <= spread_max <= %v",
+			usecase.ErrInvalidInput, favoriteSpreadMax)
+	}
+	return b.fetcher.GetSpot(usdt, spreadMin, spreadMax)
+}
+
+func (b TaskUseCase) GetSessions(prefix string) []entity.Session {
+	sessions := b.dbAdapter.SelectSessions(prefix)
+	if sessions == nil {
+		return []entity.Session{}
+	}
+	return sessions
+}
+
+func (b TaskUseCase) GetSession(id string) (entity.Session, error) {
+	session := b.dbAdapter.SelectSession(id)
+	if session.ID == "" {
+		return entity.Session{}, fmt.Errorf("session %v: %w", id, usecase.ErrNotFound)
+	}
+	return session, nil
+}
+
+func (b TaskUseCase) GetCard(id, marketFrom, marketTo, symbol string) (string, error) {
+	transaction := b.dbAdapter.SelectTransactionsBySymbol(id, symbol, marketFrom, marketTo)
+	if transaction.ID == "" {
+		return "", fmt.Errorf("deal %v %v → %v: %w", symbol, marketFrom, marketTo, usecase.ErrNotFound)
+	}
+	return b.cards.Card(transaction)
+}
+
+func (b TaskUseCase) SetSessionFilters(id string, filters entity.SessionFilters) (entity.SessionFilters, error) {
+	if _, err := b.GetSession(id); err != nil {
+		return entity.SessionFilters{}, err
+	}
+
+	filters.BuyMarkets = b.compactList(filters.BuyMarkets, strings.ToUpper)
+	filters.SellMarkets = b.compactList(filters.SellMarkets, strings.ToUpper)
+	filters.IncludeChains = b.compactList(filters.IncludeChains, entity.NormalizeChain)
+	filters.ExcludeChains = b.compactList(filters.ExcludeChains, entity.NormalizeChain)
+
+	for _, market := range slices.Concat(filters.BuyMarkets, filters.SellMarkets) {
+		if !slices.Contains(entity.Markets, market) {
+			return entity.SessionFilters{}, fmt.Errorf("%w: unknown market %v", usecase.ErrInvalidInput, market)
+		}
+	}
+	if !filters.Guards.Valid() {
+		return entity.SessionFilters{}, fmt.Errorf("%w: guards must be finite and not negative",
+			usecase.ErrInvalidInput)
+	}
+
+	if err := b.dbAdapter.UpdateSessionMarkets(id, filters.BuyMarkets, filters.SellMarkets); err != nil {
+		return entity.SessionFilters{}, err
+	}
+	if err := b.dbAdapter.UpdateSessionChains(id, filters.IncludeChains, filters.ExcludeChains); err != nil {
+		return entity.SessionFilters{}, err
+	}
+	if err := b.dbAdapter.UpdateSessionGuards(id, filters.Guards); err != nil {
+		return entity.SessionFilters{}, err
+	}
+	return filters, nil
+}
+
+func (b TaskUseCase) compactList(values []string, normalize func(string) string) []string {
+	list := []string{}
+	for _, value := range values {
+		value = normalize(value)
+		if !slices.Contains(list, value) {
+			list = append(list, value)
+		}
+	}
+	return list
+}
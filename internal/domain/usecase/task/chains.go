@@ -0,0 +1,46 @@
+package task
+
+import (
+	"crypto_pro/internal/domain/entity"
+	"slices"
+)
+
+func (b TaskUseCase) SetIncludeChains(id, requestIn string) string {
+	session := b.dbAdapter.SelectSession(id)
+	if session.ID == "" {
+		return "Нет активной сессии."
+	}
+
+	chains := b.getChainsIn(requestIn, "ALL")
+	if err := b.dbAdapter.UpdateSessionChains(id, chains, session.ExcludeChains); err != nil {
+		return "Не удалось сохранить сети."
+	}
+	return "Только сети: " + b.formatMarkets(chains)
+}
+
+func (b TaskUseCase) SetExcludeChains(id, requestIn string) string {
+	session := b.dbAdapter.SelectSession(id)
+	if session.ID == "" {
+		return "Нет активной сессии."
+	}
+
+	chains := b.getChainsIn(requestIn, "NONE")
+	if err := b.dbAdapter.UpdateSessionChains(id, session.IncludeChains, chains); err != nil {
+		return "Не удалось сохранить сети."
+	}
+	return "Исключенные сети: " + b.formatSymbols(chains)
+}
+
+func (b TaskUseCase) getChainsIn(input, resetWord string) []string {
+	chains := []string{}
+	for _, field := range b.splitList(input) {
+		if field == resetWord {
+			return []string{}
+		}
+		chain := entity.NormalizeChain(field)
+		if !slices.Contains(chains, chain) {
+			chains = append(chains, chain)
+		}
+	}
+	return chains
+}
@@ -0,0 +1,25 @@
+package task
+
+import (
+	"crypto_pro/internal/chart"
+	"errors"
+	"fmt"
+	"time"
+)
+
+func (b TaskUseCase) GetSpreadChart(id, marketFrom, marketTo, symbol, chain string) ([]byte, string) {
+	points := b.dbAdapter.SelectSpreadHistory(id, symbol, chain, marketFrom, marketTo, b.charts.Since(time.Now()))
+	title := fmt.Sprintf("%v (%v) %v -> %v, %vh", symbol, chain, marketFrom, marketTo, b.charts.Hours())
+
+	image, err := b.charts.Render(title, points)
+	if errors.Is(err, chart.ErrNotEnoughPoints) {
+		return nil, "Пока недостаточно истории для графика, попробуйте позже."
+	}
+	if err != nil {
+		b.log.Error("Error when rendering chart", b.log.ErrorC(err))
+		return nil, "Не удалось построить график."
+	}
+
+	return image, fmt.Sprintf("📈 %v (%v) %v → %v за %v ч. (%v точек)", symbol, chain, marketFrom, marketTo,
+		b.charts.Hours(), len(points))
+}
@@ -0,0 +1,17 @@
+package task
+
+import "fmt"
+
+func (b TaskUseCase) SetChatThread(chatID string, threadID int) string {
+	if err := b.dbAdapter.UpsertChatThread(chatID, threadID); err != nil {
+		return "Не удалось сохранить тему."
+	}
+	if threadID == 0 {
+		return "Сделки будут публиковаться в общий чат."
+	}
+	return fmt.Sprintf("Сделки будут публиковаться в тему #%v.", threadID)
+}
+
+func (b TaskUseCase) GetChatThread(chatID string) int {
+	return b.dbAdapter.SelectChatThread(chatID)
+}
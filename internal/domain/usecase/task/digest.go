@@ -0,0 +1,113 @@
+package task
+
+import (
+	"crypto_pro/internal/domain/entity"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const digestLimit = 5
+
+func (b TaskUseCase) SetDigest(chatID, userID, requestIn string) string {
+	fields := strings.Fields(strings.ToLower(requestIn))
+	if len(fields) == 0 {
+		digest := b.dbAdapter.SelectDigest(userID)
+		if digest.Period == "" {
+			return "📊 Дайджест выключен. Пример: /digest daily 09:00 или /digest weekly mon 09:00"
+		}
+		user, _ := b.dbAdapter.SelectUser(userID)
+		return b.formatDigest(digest, user)
+	}
+
+	switch fields[0] {
+	case "off":
+		if err := b.dbAdapter.DeleteDigest(userID); err != nil {
+			return "Не удалось выключить дайджест."
+		}
+		return "📊 Дайджест выключен."
+	case "now":
+		return b.GetDigest(userID, entity.Digest{Period: entity.DigestDaily})
+	}
+
+	digest := entity.Digest{UserID: userID, ChatID: chatID, Period: fields[0], SentAt: time.Now()}
+	switch {
+	case digest.Period == entity.DigestDaily && len(fields) == 2:
+		digest.At = fields[1]
+	case digest.Period == entity.DigestWeekly && len(fields) == 3:
+		weekday, exists := entity.Weekdays[fields[1]]
+		if !exists {
+			return fmt.Sprintf("Неизвестный день недели %s. Доступны: mon, tue, wed, thu, fri, sat, sun", fields[1])
+		}
+		digest.Weekday, digest.At = weekday, fields[2]
+	default:
+		return "Пример: /digest daily 09:00, /digest weekly mon 09:00, /digest now или /digest off"
+	}
+
+	if _, err := entity.ParseClock(digest.At); err != nil {
+		return fmt.Sprintf("Неверное время %s. Пример: 09:00", digest.At)
+	}
+
+	if err := b.dbAdapter.UpsertDigest(digest); err != nil {
+		return "Не удалось сохранить дайджест."
+	}
+	user, _ := b.dbAdapter.SelectUser(userID)
+	return b.formatDigest(digest, user)
+}
+
+func (b TaskUseCase) formatDigest(digest entity.Digest, user entity.User) string {
+	if digest.Period == entity.DigestWeekly {
+		return fmt.Sprintf("📊 Еженедельный дайджест: %v %v (%v)",
+			strings.ToLower(digest.Weekday.String()[:3]), digest.At, user.Location())
+	}
+	return fmt.Sprintf("📊 Ежедневный дайджест: %v (%v)", digest.At, user.Location())
+}
+
+func (b TaskUseCase) GetDigest(userID string, digest entity.Digest) string {
+	transactions := b.dbAdapter.SelectDigestTransactions(userID, time.Now().Add(-digest.Duration()))
+	stats := entity.NewDigestStats(transactions, digestLimit)
+
+	title := "📊 Дайджест за сутки"
+	if digest.Period == entity.DigestWeekly {
+		title = "📊 Дайджест за неделю"
+	}
+	if stats.Total == 0 {
+		return title + ": сделок не было."
+	}
+
+	msgContent := fmt.Sprintf("%v \nСделок: %v \n", title, stats.Total)
+	msgContent += "\nЛучшие спреды: \n"
+	for _, transaction := range stats.Best {
+		msgContent += fmt.Sprintf("%.2f %% — %v %v → %v (%v) \n", transaction.PeakSpread, transaction.Symbol,
+			transaction.MarketFrom, transaction.MarketTo, transaction.Chain)
+	}
+	msgContent += "\nЧастые маршруты: \n"
+	for _, route := range stats.Routes {
+		msgContent += fmt.Sprintf("%v — %v \n", route.Name, route.Value)
+	}
+	msgContent += "\nБиржи с наибольшим числом сделок: \n"
+	for _, market := range stats.Markets {
+		msgContent += fmt.Sprintf("%v — %v \n", market.Name, market.Value)
+	}
+	if stats.AvgLifetime > 0 {
+		msgContent += fmt.Sprintf("\nСредняя жизнь сделки: %v", stats.AvgLifetime.Round(time.Second))
+	}
+	return strings.TrimSuffix(msgContent, " \n")
+}
+
+func (b TaskUseCase) CheckDigests(now time.Time) []entity.DigestReport {
+	reports := []entity.DigestReport{}
+	for _, digest := range b.dbAdapter.SelectDigests() {
+		user, err := b.dbAdapter.SelectUser(digest.UserID)
+		if err != nil || !digest.DueAt(now, user.Location()) {
+			continue
+		}
+		reports = append(reports, entity.DigestReport{UserID: digest.UserID, ChatID: digest.ChatID,
+			Text: b.GetDigest(digest.UserID, digest)})
+	}
+	return reports
+}
+
+func (b TaskUseCase) MarkDigestSent(userID string, sentAt time.Time) error {
+	return b.dbAdapter.UpdateDigestSentAt(userID, sentAt)
+}
@@ -0,0 +1,90 @@
+package task
+
+import (
+	"crypto_pro/internal/domain/entity"
+	"crypto_pro/internal/export"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const defaultExportPeriod = 24 * time.Hour
+
+func (b TaskUseCase) GetExportFilter(requestIn string) (entity.ExportFilter, error) {
+	filter := entity.ExportFilter{Format: export.FormatCSV, Since: time.Now().Add(-defaultExportPeriod),
+		Status: entity.ExportStatusAll}
+
+	for _, field := range strings.Fields(requestIn) {
+		lower := strings.ToLower(field)
+		switch {
+		case lower == export.FormatCSV || lower == export.FormatXLSX:
+			filter.Format = lower
+			continue
+		case lower == entity.ExportStatusAll || lower == entity.ExportStatusOpen ||
+			lower == entity.ExportStatusClosed:
+			filter.Status = lower
+			continue
+		}
+
+		if period, err := b.getPeriodIn(lower); err == nil {
+			filter.Since = time.Now().Add(-period)
+			continue
+		}
+
+		key, value, found := strings.Cut(field, "=")
+		if !found {
+			return filter, fmt.Errorf("Не понял параметр %s. Пример: /export xlsx 7d closed symbol=BTC", field)
+		}
+
+		var err error
+		switch strings.ToLower(key) {
+		case "symbol":
+			filter.Symbol = strings.ToUpper(value)
+		case "from":
+			filter.MarketFrom = strings.ToUpper(value)
+		case "to":
+			filter.MarketTo = strings.ToUpper(value)
+		case "chain":
+			filter.Chain = entity.NormalizeChain(value)
+		case "spread":
+			filter.MinSpread, err = strconv.ParseFloat(value, 64)
+		default:
+			return filter, fmt.Errorf("Неизвестный параметр %s. Доступны: symbol, from, to, chain, spread", key)
+		}
+		if err != nil {
+			return filter, fmt.Errorf("Неверное значение %s для %s", value, key)
+		}
+	}
+	return filter, nil
+}
+
+func (b TaskUseCase) getPeriodIn(input string) (time.Duration, error) {
+	if days, found := strings.CutSuffix(input, "d"); found {
+		value, err := strconv.Atoi(days)
+		if err != nil || value <= 0 {
+			return 0, fmt.Errorf("invalid period %s", input)
+		}
+		return time.Duration(value) * 24 * time.Hour, nil
+	}
+
+	period, err := time.ParseDuration(input)
+	if err != nil || period <= 0 {
+		return 0, fmt.Errorf("invalid period %s", input)
+	}
+	return period, nil
+}
+
+func (b TaskUseCase) ExportTransactions(id string, filter entity.ExportFilter, w io.Writer) error {
+	writer, err := export.NewWriter(filter.Format, w)
+	if err != nil {
+		return err
+	}
+
+	if err := b.dbAdapter.StreamTransactions(id, filter, writer.Write); err != nil {
+		b.log.Error("Error when exporting transactions", b.log.ErrorC(err))
+		return err
+	}
+	return writer.Close()
+}
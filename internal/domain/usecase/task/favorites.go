@@ -0,0 +1,165 @@
+package task
+
+import (
+	"crypto_pro/internal/domain/entity"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const favoriteSpreadMax = 100
+
+func (b TaskUseCase) AddFavorite(userID, id, marketFrom, marketTo, symbol string) string {
+	transaction := b.dbAdapter.SelectTransactionsBySymbol(id, symbol, marketFrom, marketTo)
+	session := b.dbAdapter.SelectSession(id)
+	if transaction.ID == "" || session.ID == "" {
+		return "Сделка уже не отслеживается"
+	}
+
+	chatID, _, _ := entity.ParseSessionID(id)
+	favorite := entity.Favorite{UserID: userID, ChatID: chatID, Symbol: transaction.Symbol,
+		Chain: transaction.Chain, MarketFrom: transaction.MarketFrom, MarketTo: transaction.MarketTo,
+		USDT: session.USDT, Threshold: session.SpreadMin, Spread: transaction.Spread,
+		IsAbove: transaction.Spread >= session.SpreadMin}
+
+	if err := b.dbAdapter.InsertFavorite(favorite); err != nil {
+		return "Не удалось сохранить избранное."
+	}
+	return fmt.Sprintf("⭐ Добавлено в избранное, порог %v %%", favorite.Threshold)
+}
+
+func (b TaskUseCase) GetFavorites(userID string) string {
+	favorites := b.dbAdapter.SelectUserFavorites(userID)
+	if len(favorites) == 0 {
+		return "⭐ Избранных сделок нет. Добавьте их кнопкой «⭐» на карточке сделки."
+	}
+
+	now := time.Now()
+	msgContent := "⭐ Избранные сделки: \n"
+	for _, favorite := range favorites {
+		status := "нет данных"
+		if !favorite.CheckedAt.IsZero() {
+			status = "нет предложения"
+			if favorite.Spread > 0 {
+				status = fmt.Sprintf("спред %.2f %%", favorite.Spread)
+			}
+			status += fmt.Sprintf(" (%v мин назад)", int(now.Sub(favorite.CheckedAt).Minutes()))
+		}
+		msgContent += fmt.Sprintf("#%v: %v, %v, порог %v %% \n", favorite.ID, favorite.Route(), status,
+			favorite.Threshold)
+	}
+	return msgContent + "Порог: /favorites <номер> <спред>, удалить: /unfavorite <номер>"
+}
+
+func (b TaskUseCase) SetFavoriteThreshold(userID, requestIn string) string {
+	fields := strings.Fields(requestIn)
+	if len(fields) != 2 {
+		return "Пример: /favorites 3 1.5"
+	}
+
+	id, err := strconv.ParseInt(strings.TrimPrefix(fields[0], "#"), 10, 64)
+	if err != nil {
+		return "Укажите номер избранной сделки, например: /favorites 3 1.5"
+	}
+	threshold, err := strconv.ParseFloat(fields[1], 64)
+	if err != nil {
+		return fmt.Sprintf("Неверное значение порога %s", fields[1])
+	}
+
+	if !b.dbAdapter.UpdateFavoriteThreshold(userID, id, threshold) {
+		return "Избранная сделка не найдена."
+	}
+	return fmt.Sprintf("⭐ Порог #%v: %v %%", id, threshold)
+}
+
+func (b TaskUseCase) DeleteFavorite(userID, requestIn string) string {
+	id, err := strconv.ParseInt(strings.TrimPrefix(strings.TrimSpace(requestIn), "#"), 10, 64)
+	if err != nil {
+		return "Укажите номер избранной сделки, например: /unfavorite 3"
+	}
+	if !b.dbAdapter.DeleteFavorite(userID, id) {
+		return "Избранная сделка не найдена."
+	}
+	return "Удалено из избранного."
+}
+
+func (b TaskUseCase) CheckFavorites() []entity.FavoriteAlert {
+	favorites := b.dbAdapter.SelectFavorites()
+	if len(favorites) == 0 {
+		return nil
+	}
+
+	current, unknown := b.getFavoriteSpreads(favorites)
+	alerts := []entity.FavoriteAlert{}
+	quiet := map[string]bool{}
+	for _, favorite := range favorites {
+		if unknown[favorite.ID] {
+			continue
+		}
+
+		spread, exists := current[favorite.ID]
+		isAbove := exists && spread >= favorite.Threshold
+		if isAbove != favorite.IsAbove {
+			isQuiet, known := quiet[favorite.UserID]
+			if !known {
+				user, err := b.dbAdapter.SelectUser(favorite.UserID)
+				isQuiet = err != nil || user.IsQuiet(time.Now())
+				quiet[favorite.UserID] = isQuiet
+			}
+			if isQuiet {
+				isAbove = favorite.IsAbove
+			}
+		}
+
+		msgContent := ""
+		if isAbove != favorite.IsAbove {
+			changed := favorite
+			changed.Spread, changed.IsAbove = spread, isAbove
+
+			var err error
+			if msgContent, err = b.cards.Favorite(changed); err != nil {
+				b.log.Error("failed to render favorite alert", b.log.ErrorC(err))
+				continue
+			}
+		}
+
+		if err := b.dbAdapter.UpdateFavoriteState(favorite.ID, spread, isAbove); err != nil || msgContent == "" {
+			continue
+		}
+		alerts = append(alerts, entity.FavoriteAlert{ChatID: favorite.ChatID, Text: msgContent})
+	}
+	return alerts
+}
+
+func (b TaskUseCase) getFavoriteSpreads(favorites []entity.Favorite) (map[int64]float64, map[int64]bool) {
+	transactionsByUSDT := map[float64][]entity.Transaction{}
+	failedUSDT := map[float64]bool{}
+	spreads := map[int64]float64{}
+	unknown := map[int64]bool{}
+	for _, favorite := range favorites {
+		transactions, exists := transactionsByUSDT[favorite.USDT]
+		if !exists && !failedUSDT[favorite.USDT] {
+			var err error
+			transactions, err = b.fetcher.GetSpot(favorite.USDT, 0, favoriteSpreadMax)
+			if err != nil {
+				failedUSDT[favorite.USDT] = true
+			}
+			for i := range transactions {
+				transactions[i].NormalizeChain()
+			}
+			transactionsByUSDT[favorite.USDT] = transactions
+		}
+		if failedUSDT[favorite.USDT] {
+			unknown[favorite.ID] = true
+			continue
+		}
+
+		for _, transaction := range transactions {
+			if favorite.Matches(transaction) && transaction.Spread > spreads[favorite.ID] {
+				spreads[favorite.ID] = transaction.Spread
+			}
+		}
+	}
+	return spreads, unknown
+}
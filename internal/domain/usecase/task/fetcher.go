@@ -0,0 +1,135 @@
+package task
+
+import (
+	"crypto_pro/internal/controller"
+	"crypto_pro/internal/domain/entity"
+	"crypto_pro/internal/metrics"
+	"sync"
+	"time"
+)
+
+const (
+	defaultShareTTL      = 100 * time.Second
+	defaultShareMaxWidth = 10
+)
+
+type spreadRange struct {
+	min float64
+	max float64
+}
+
+type spotFetch struct {
+	done         chan struct{}
+	at           time.Time
+	spread       spreadRange
+	transactions []entity.Transaction
+	err          error
+}
+
+// SpotFetcher shares one spot request between callers asking for the same
+// amount. Order books and costs depend on the amount, so different amounts
+// are never shared. Spread ranges not wider than maxWidth are remembered for
+// 2×ttl and merged into the next fetch for that amount. A completed fetch is
+// reused for ttl, so the spot server needs no cache of its own.
+type SpotFetcher struct {
+	serverController controller.Server
+	ttl              time.Duration
+	maxWidth         float64
+	mu               sync.Mutex
+	fetches          map[float64]*spotFetch
+	ranges           map[float64]map[spreadRange]time.Time
+}
+
+func NewSpotFetcher(serverController controller.Server, ttl time.Duration, maxWidth float64) *SpotFetcher {
+	if ttl <= 0 {
+		ttl = defaultShareTTL
+	}
+	if maxWidth <= 0 {
+		maxWidth = defaultShareMaxWidth
+	}
+	return &SpotFetcher{serverController: serverController, ttl: ttl, maxWidth: maxWidth,
+		fetches: map[float64]*spotFetch{}, ranges: map[float64]map[spreadRange]time.Time{}}
+}
+
+func (f *SpotFetcher) GetSpot(usdt, spreadMin, spreadMax float64) ([]entity.Transaction, error) {
+	requested := spreadRange{min: spreadMin, max: spreadMax}
+	now := time.Now()
+
+	f.mu.Lock()
+	f.prune(now)
+	if requested.max-requested.min <= f.maxWidth {
+		if f.ranges[usdt] == nil {
+			f.ranges[usdt] = map[spreadRange]time.Time{}
+		}
+		f.ranges[usdt][requested] = now
+	}
+
+	fetch := f.fetches[usdt]
+	if fetch == nil || !fetch.covers(requested) || fetch.stale(now, f.ttl) {
+		fetch = f.start(usdt, requested, now)
+		metrics.SpotFetches.WithLabelValues("fetch").Inc()
+	} else {
+		metrics.SpotFetches.WithLabelValues("shared").Inc()
+	}
+	f.mu.Unlock()
+
+	<-fetch.done
+	if fetch.err != nil {
+		return nil, fetch.err
+	}
+
+	transactions := []entity.Transaction{}
+	for _, transaction := range fetch.transactions {
+		if transaction.Spread >= spreadMin && transaction.Spread <= spreadMax {
+			transactions = append(transactions, transaction)
+		}
+	}
+	return transactions, nil
+}
+
+func (f *SpotFetcher) start(usdt float64, requested spreadRange, now time.Time) *spotFetch {
+	widest := requested
+	for spread := range f.ranges[usdt] {
+		widest.min = min(widest.min, spread.min)
+		widest.max = max(widest.max, spread.max)
+	}
+
+	fetch := &spotFetch{done: make(chan struct{}), at: now, spread: widest}
+	f.fetches[usdt] = fetch
+	go func() {
+		defer close(fetch.done)
+		fetch.transactions, fetch.err = f.serverController.GetSpotHandler(usdt, widest.min, widest.max)
+	}()
+	return fetch
+}
+
+func (f *SpotFetcher) prune(now time.Time) {
+	for usdt, fetch := range f.fetches {
+		if fetch.stale(now, f.ttl) {
+			delete(f.fetches, usdt)
+		}
+	}
+	for usdt, spreads := range f.ranges {
+		for spread, seenAt := range spreads {
+			if now.Sub(seenAt) > 2*f.ttl {
+				delete(spreads, spread)
+			}
+		}
+		if len(spreads) == 0 {
+			delete(f.ranges, usdt)
+		}
+	}
+}
+
+func (s *spotFetch) covers(requested spreadRange) bool {
+	return s.spread.min <= requested.min && s.spread.max >= requested.max
+}
+
+func (s *spotFetch) stale(now time.Time, ttl time.Duration) bool {
+	select {
+	case <-s.done:
+		return s.err != nil || now.Sub(s.at) > ttl
+	default:
+		return false
+	}
+}
@@ -0,0 +1,241 @@
+package task
+
+import (
+	"crypto_pro/internal/controller"
+	"crypto_pro/internal/domain/entity"
+	"crypto_pro/internal/metrics"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+type spotCall struct {
+	usdt      float64
+	spreadMin float64
+	spreadMax float64
+}
+
+type fakeServer struct {
+	controller.Server
+	mu      sync.Mutex
+	calls   []spotCall
+	err     error
+	started chan struct{}
+	release chan struct{}
+}
+
+func (s *fakeServer) GetSpotHandler(usdt, spreadMin, spreadMax float64) ([]entity.Transaction, error) {
+	if s.release != nil {
+		s.started <- struct{}{}
+		<-s.release
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.calls = append(s.calls, spotCall{usdt: usdt, spreadMin: spreadMin, spreadMax: spreadMax})
+	if s.err != nil {
+		return nil, s.err
+	}
+	transactions := []entity.Transaction{}
+	for _, spread := range []float64{0.5, 1.5, 3, 7, 20} {
+		if spread >= spreadMin && spread <= spreadMax {
+			transactions = append(transactions, entity.Transaction{Spread: spread, AskCost: usdt})
+		}
+	}
+	return transactions, nil
+}
+
+func (s *fakeServer) getCalls() []spotCall {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]spotCall{}, s.calls...)
+}
+
+func getSpot(t *testing.T, fetcher *SpotFetcher, usdt, spreadMin, spreadMax float64) []entity.Transaction {
+	t.Helper()
+
+	transactions, err := fetcher.GetSpot(usdt, spreadMin, spreadMax)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, transaction := range transactions {
+		if transaction.Spread < spreadMin || transaction.Spread > spreadMax {
+			t.Errorf("spread %v outside %v..%v", transaction.Spread, spreadMin, spreadMax)
+		}
+		if transaction.AskCost != usdt {
+			t.Errorf("transaction fetched for %v, want %v", transaction.AskCost, usdt)
+		}
+	}
+	return transactions
+}
+
+func assertCalls(t *testing.T, server *fakeServer, want ...spotCall) {
+	t.Helper()
+
+	got := server.getCalls()
+	if len(got) != len(want) {
+		t.Fatalf("calls = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("call %v = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestFetcherSharesSameAmount(t *testing.T) {
+	server := &fakeServer{}
+	fetcher := NewSpotFetcher(server, time.Minute, 10)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			fetcher.GetSpot(1000, 1, 5)
+		}()
+	}
+	wg.Wait()
+	if calls := server.getCalls(); len(calls) != 1 {
+		t.Fatalf("calls = %v, want one shared fetch", calls)
+	}
+
+	if got := getSpot(t, fetcher, 1000, 2, 4); len(got) != 1 {
+		t.Errorf("covered range returned %v transactions, want 1", len(got))
+	}
+	assertCalls(t, server, spotCall{usdt: 1000, spreadMin: 1, spreadMax: 5})
+}
+
+func TestFetcherSharesInFlightFetch(t *testing.T) {
+	server := &fakeServer{started: make(chan struct{}, 1), release: make(chan struct{})}
+	fetcher := NewSpotFetcher(server, time.Minute, 10)
+	fetched := testutil.ToFloat64(metrics.SpotFetches.WithLabelValues("fetch"))
+	shared := testutil.ToFloat64(metrics.SpotFetches.WithLabelValues("shared"))
+
+	var wg sync.WaitGroup
+	results := make(chan int, 5)
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			transactions, err := fetcher.GetSpot(1000, 1, 5)
+			if err != nil {
+				t.Error(err)
+			}
+			results <- len(transactions)
+		}()
+		if i == 0 {
+			<-server.started
+		}
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for testutil.ToFloat64(metrics.SpotFetches.WithLabelValues("shared"))-shared < 4 {
+		if time.Now().After(deadline) {
+			t.Fatal("callers did not join the in-flight fetch")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	close(server.release)
+	wg.Wait()
+	close(results)
+
+	for count := range results {
+		if count != 2 {
+			t.Errorf("caller got %v transactions, want 2", count)
+		}
+	}
+	assertCalls(t, server, spotCall{usdt: 1000, spreadMin: 1, spreadMax: 5})
+	if got := testutil.ToFloat64(metrics.SpotFetches.WithLabelValues("fetch")) - fetched; got != 1 {
+		t.Errorf("fetch count = %v, want 1", got)
+	}
+}
+
+func TestFetcherSeparatesAmounts(t *testing.T) {
+	server := &fakeServer{}
+	fetcher := NewSpotFetcher(server, time.Minute, 10)
+
+	getSpot(t, fetcher, 1000, 1, 5)
+	getSpot(t, fetcher, 900, 1, 5)
+	getSpot(t, fetcher, 1000.5, 1, 5)
+	assertCalls(t, server, spotCall{usdt: 1000, spreadMin: 1, spreadMax: 5},
+		spotCall{usdt: 900, spreadMin: 1, spreadMax: 5}, spotCall{usdt: 1000.5, spreadMin: 1, spreadMax: 5})
+}
+
+func TestFetcherWidensNarrowRanges(t *testing.T) {
+	server := &fakeServer{}
+	fetcher := NewSpotFetcher(server, time.Minute, 10)
+
+	getSpot(t, fetcher, 1000, 1, 2)
+	getSpot(t, fetcher, 1000, 2, 5)
+	getSpot(t, fetcher, 1000, 1, 5)
+	getSpot(t, fetcher, 1000, 1.5, 4)
+	assertCalls(t, server, spotCall{usdt: 1000, spreadMin: 1, spreadMax: 2},
+		spotCall{usdt: 1000, spreadMin: 1, spreadMax: 5})
+}
+
+func TestFetcherLongRangeDoesNotWiden(t *testing.T) {
+	server := &fakeServer{}
+	fetcher := NewSpotFetcher(server, 20*time.Millisecond, 10)
+
+	if got := getSpot(t, fetcher, 1000, 0, 100); len(got) != 5 {
+		t.Errorf("long range returned %v transactions, want 5", len(got))
+	}
+	getSpot(t, fetcher, 1000, 1, 5)
+
+	time.Sleep(30 * time.Millisecond)
+	getSpot(t, fetcher, 1000, 1, 5)
+	assertCalls(t, server, spotCall{usdt: 1000, spreadMin: 0, spreadMax: 100},
+		spotCall{usdt: 1000, spreadMin: 1, spreadMax: 5})
+}
+
+func TestFetcherRefetchesAfterTTL(t *testing.T) {
+	server := &fakeServer{}
+	fetcher := NewSpotFetcher(server, 20*time.Millisecond, 10)
+
+	getSpot(t, fetcher, 1000, 1, 5)
+	time.Sleep(30 * time.Millisecond)
+	getSpot(t, fetcher, 1000, 1, 5)
+	if calls := server.getCalls(); len(calls) != 2 {
+		t.Fatalf("calls = %v, want a fetch per ttl", calls)
+	}
+}
+
+func TestFetcherPrunes(t *testing.T) {
+	server := &fakeServer{}
+	fetcher := NewSpotFetcher(server, 20*time.Millisecond, 10)
+
+	getSpot(t, fetcher, 1000, 1, 5)
+	getSpot(t, fetcher, 900, 1, 5)
+	time.Sleep(50 * time.Millisecond)
+	getSpot(t, fetcher, 500, 1, 5)
+
+	fetcher.mu.Lock()
+	defer fetcher.mu.Unlock()
+	if len(fetcher.fetches) != 1 || fetcher.fetches[500] == nil {
+		t.Errorf("fetches not pruned: %v", fetcher.fetches)
+	}
+	if len(fetcher.ranges) != 1 || fetcher.ranges[500] == nil {
+		t.Errorf("ranges not pruned: %v", fetcher.ranges)
+	}
+}
+
+func TestFetcherDoesNotShareErrors(t *testing.T) {
+	server := &fakeServer{err: errors.New("unavailable")}
+	fetcher := NewSpotFetcher(server, time.Minute, 10)
+
+	if _, err := fetcher.GetSpot(1000, 1, 5); err == nil {
+		t.Fatal("expected error")
+	}
+	server.mu.Lock()
+	server.err = nil
+	server.mu.Unlock()
+
+	getSpot(t, fetcher, 1000, 1, 5)
+	if calls := server.getCalls(); len(calls) != 2 {
+		t.Fatalf("calls = %v, want retry after error", calls)
+	}
+}
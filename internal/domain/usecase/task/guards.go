@@ -0,0 +1,82 @@
+package task
+
+import (
+	"crypto_pro/internal/domain/entity"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+func (b TaskUseCase) SetGuards(id, requestIn string) string {
+	session := b.dbAdapter.SelectSession(id)
+	if session.ID == "" {
+		return "Нет активной сессии."
+	}
+
+	if strings.TrimSpace(requestIn) == "" {
+		return b.formatGuards(session.Guards)
+	}
+
+	guards, err := b.getGuardsIn(requestIn, session.Guards)
+	if err != nil {
+		return err.Error()
+	}
+
+	if err := b.dbAdapter.UpdateSessionGuards(id, guards); err != nil {
+		return "Не удалось сохранить фильтры сделок."
+	}
+	return b.formatGuards(guards)
+}
+
+func (b TaskUseCase) getGuardsIn(input string, guards entity.Guards) (entity.Guards, error) {
+	for _, field := range strings.Fields(strings.ToLower(input)) {
+		if field == "off" {
+			guards = entity.Guards{}
+			continue
+		}
+
+		key, value, found := strings.Cut(field, "=")
+		if !found {
+			return guards, fmt.Errorf("Не понял параметр %s. Пример: /guards fee=2 withdraw=on orders=2 depth=50",
+				field)
+		}
+
+		var err error
+		switch key {
+		case "fee":
+			guards.MaxFeePercent, err = strconv.ParseFloat(value, 64)
+		case "withdraw":
+			if value != "on" && value != "off" {
+				return guards, fmt.Errorf("Неверное значение %s для withdraw. Доступны: on, off", value)
+			}
+			guards.CheckWithdrawMax = value == "on"
+		case "orders":
+			guards.MinOrders, err = strconv.Atoi(value)
+		case "depth":
+			guards.MinDepthUSDT, err = strconv.ParseFloat(value, 64)
+		default:
+			return guards, fmt.Errorf("Неизвестный параметр %s. Доступны: fee, withdraw, orders, depth", key)
+		}
+		if err != nil || !guards.Valid() {
+			return guards, fmt.Errorf("Неверное значение %s для %s: нужно неотрицательное число", value, key)
+		}
+	}
+	return guards, nil
+}
+
+func (b TaskUseCase) formatGuards(guards entity.Guards) string {
+	msgContent := "🛡 Фильтры сделок \n"
+	if guards.MaxFeePercent > 0 {
+		msgContent += fmt.Sprintf("Комиссия вывода: не более %v %% от объема \n", guards.MaxFeePercent)
+	} else {
+		msgContent += "Комиссия вывода: без ограничения \n"
+	}
+	if guards.CheckWithdrawMax {
+		msgContent += "Объем не больше лимита вывода: да \n"
+	} else {
+		msgContent += "Объем не больше лимита вывода: нет \n"
+	}
+	msgContent += fmt.Sprintf("Мин. кол-во ордеров с каждой стороны: %v \n", guards.MinOrders)
+	msgContent += fmt.Sprintf("Мин. глубина: %v USDT", guards.MinDepthUSDT)
+	return msgContent
+}
@@ -0,0 +1,49 @@
+package task
+
+import (
+	"crypto_pro/internal/domain/entity"
+	"testing"
+)
+
+func TestGetGuardsIn(t *testing.T) {
+	current := entity.Guards{MaxFeePercent: 1, MinOrders: 1}
+	tests := []struct {
+		input string
+		want  entity.Guards
+		err   bool
+	}{
+		{input: "fee=2 withdraw=on orders=3 depth=50",
+			want: entity.Guards{MaxFeePercent: 2, CheckWithdrawMax: true, MinOrders: 3, MinDepthUSDT: 50}},
+		{input: "depth=100", want: entity.Guards{MaxFeePercent: 1, MinOrders: 1, MinDepthUSDT: 100}},
+		{input: "off", want: entity.Guards{}},
+		{input: "off withdraw=on", want: entity.Guards{CheckWithdrawMax: true}},
+		{input: "withdraw=off", want: current},
+		{input: "withdraw=yes", err: true},
+		{input: "fee=-1", err: true},
+		{input: "fee=NaN", err: true},
+		{input: "fee=Inf", err: true},
+		{input: "depth=-5", err: true},
+		{input: "depth=+Inf", err: true},
+		{input: "orders=-2", err: true},
+		{input: "orders=1.5", err: true},
+		{input: "fee", err: true},
+		{input: "speed=2", err: true},
+	}
+
+	for _, test := range tests {
+		got, err := TaskUseCase{}.getGuardsIn(test.input, current)
+		if test.err {
+			if err == nil {
+				t.Errorf("%q: expected error, got %+v", test.input, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%q: %v", test.input, err)
+			continue
+		}
+		if got != test.want {
+			t.Errorf("%q = %+v, want %+v", test.input, got, test.want)
+		}
+	}
+}
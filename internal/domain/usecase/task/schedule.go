@@ -0,0 +1,222 @@
+package task
+
+import (
+	"crypto_pro/internal/domain/entity"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+func (b TaskUseCase) SetTimeZone(userID, requestIn string) string {
+	timeZone := strings.TrimSpace(requestIn)
+	if timeZone == "" {
+		user, _ := b.dbAdapter.SelectUser(userID)
+		return fmt.Sprintf("Часовой пояс: %v", user.Location())
+	}
+
+	if _, err := time.LoadLocation(timeZone); err != nil {
+		return fmt.Sprintf("Неизвестный часовой пояс %s. Пример: /tz Europe/Moscow", timeZone)
+	}
+
+	user, err := b.dbAdapter.SelectUser(userID)
+	if err != nil {
+		return "Не удалось сохранить часовой пояс."
+	}
+	user.TimeZone = timeZone
+	if err := b.dbAdapter.UpsertUserSettings(user); err != nil {
+		return "Не удалось сохранить часовой пояс."
+	}
+	return fmt.Sprintf("Часовой пояс: %v", timeZone)
+}
+
+func (b TaskUseCase) SetQuietHours(userID, requestIn string) string {
+	user, err := b.dbAdapter.SelectUser(userID)
+	if err != nil {
+		return "Не удалось загрузить тихие часы, попробуйте позже."
+	}
+
+	input := strings.TrimSpace(requestIn)
+	switch input {
+	case "":
+		return b.formatQuietHours(user)
+	case "off":
+		user.QuietFrom, user.QuietTo = "", ""
+	default:
+		from, to, err := b.getWindowIn(input)
+		if err != nil {
+			return "Не понял время. Пример: /quiet 23:00-08:00"
+		}
+		user.QuietFrom, user.QuietTo = from, to
+	}
+
+	if err := b.dbAdapter.UpsertUserSettings(user); err != nil {
+		return "Не удалось сохранить тихие часы."
+	}
+	return b.formatQuietHours(user)
+}
+
+func (b TaskUseCase) formatQuietHours(user entity.User) string {
+	if user.QuietFrom == "" {
+		return "🌙 Тихие часы: выключены"
+	}
+	return fmt.Sprintf("🌙 Тихие часы: %v-%v (%v)", user.QuietFrom, user.QuietTo, user.Location())
+}
+
+func (b TaskUseCase) getWindowIn(input string) (string, string, error) {
+	from, to, found := strings.Cut(input, "-")
+	if !found {
+		return "", "", fmt.Errorf("invalid window %q", input)
+	}
+	start, err := entity.ParseClock(from)
+	if err != nil {
+		return "", "", err
+	}
+	stop, err := entity.ParseClock(to)
+	if err != nil {
+		return "", "", err
+	}
+	if start == stop {
+		return "", "", fmt.Errorf("empty window %q", input)
+	}
+	return from, to, nil
+}
+
+func (b TaskUseCase) GetQuietSummary(id string) (string, []entity.Transaction) {
+	session := b.dbAdapter.SelectSession(id)
+	if !session.QuietPending {
+		return "", nil
+	}
+	user, err := b.dbAdapter.SelectUser(session.OwnerID())
+	if err != nil || user.IsQuiet(time.Now()) {
+		return "", nil
+	}
+
+	if cleared, err := b.dbAdapter.UpdateSessionQuietPending(id, false); err != nil || !cleared {
+		return "", nil
+	}
+
+	closedTransactions := b.dbAdapter.SelectClosedTransactions(id)
+
+	_, _, name := entity.ParseSessionID(id)
+	msgContent := fmt.Sprintf("🌅 Тихие часы закончились (%v). \n", name)
+	msgContent += fmt.Sprintf("Закрылось отправленных сделок: %v \n", len(closedTransactions))
+	if len(closedTransactions) != 0 {
+		best := slices.MaxFunc(closedTransactions, func(a, b entity.Transaction) int {
+			switch {
+			case a.PeakSpread < b.PeakSpread:
+				return -1
+			case a.PeakSpread > b.PeakSpread:
+				return 1
+			}
+			return 0
+		})
+		msgContent += fmt.Sprintf("Лучший пиковый спред: %.2f %% (%v %v → %v) \n", best.PeakSpread, best.Symbol,
+			best.MarketFrom, best.MarketTo)
+	}
+	msgContent += "Новые и изменившиеся сделки — ниже."
+	return msgContent, closedTransactions
+}
+
+func (b TaskUseCase) AddSchedule(chatID, ownerID, requestIn string) string {
+	fields := strings.Fields(requestIn)
+	name := entity.DefaultSessionName
+	if len(fields) == 6 {
+		name, fields = fields[0], fields[1:]
+	}
+	if len(fields) != 5 || !entity.SessionNameRe.MatchString(name) {
+		return "Пример: /schedule [имя] 100 0.3 1 mon,tue,wed,thu,fri 09:00-18:00"
+	}
+
+	request := strings.Join(fields[:3], " ")
+	for _, field := range fields[:3] {
+		if _, err := strconv.ParseFloat(field, 64); err != nil {
+			return "Неверные параметры сессии. Пример: /schedule 100 0.3 1 mon,fri 09:00-18:00"
+		}
+	}
+
+	weekdays := []time.Weekday{}
+	for _, name := range strings.Split(strings.ToLower(fields[3]), ",") {
+		weekday, exists := entity.Weekdays[name]
+		if !exists {
+			return fmt.Sprintf("Неизвестный день недели %s. Доступны: mon, tue, wed, thu, fri, sat, sun", name)
+		}
+		if !slices.Contains(weekdays, weekday) {
+			weekdays = append(weekdays, weekday)
+		}
+	}
+
+	start, stop, err := b.getWindowIn(fields[4])
+	if err != nil {
+		return "Не понял время. Пример: /schedule 100 0.3 1 mon,fri 09:00-18:00"
+	}
+
+	if err := b.dbAdapter.InsertSchedule(entity.Schedule{
+		SessionID: entity.NewSessionID(chatID, ownerID, name),
+		Request:   request,
+		Weekdays:  weekdays,
+		Start:     start,
+		Stop:      stop,
+	}); err != nil {
+		return "Не удалось сохранить расписание."
+	}
+	return "Расписание сохранено. \n" + b.GetSchedules(chatID, ownerID)
+}
+
+func (b TaskUseCase) GetSchedules(chatID, ownerID string) string {
+	schedules := b.dbAdapter.SelectOwnerSchedules(entity.SessionPrefix(chatID, ownerID))
+	if len(schedules) == 0 {
+		return "🗓 Расписаний нет."
+	}
+
+	user, _ := b.dbAdapter.SelectUser(ownerID)
+	msgContent := fmt.Sprintf("🗓 Расписания (%v): \n", user.Location())
+	for _, schedule := range schedules {
+		days := []string{}
+		for _, weekday := range schedule.Weekdays {
+			days = append(days, strings.ToLower(weekday.String()[:3]))
+		}
+		_, _, name := entity.ParseSessionID(schedule.SessionID)
+		msgContent += fmt.Sprintf("#%v: %v — %v, %v, %v-%v \n", schedule.ID, name, schedule.Request,
+			strings.Join(days, ","), schedule.Start, schedule.Stop)
+	}
+	return msgContent + "Удалить: /unschedule <номер>"
+}
+
+func (b TaskUseCase) DeleteSchedule(chatID, ownerID, requestIn string) (string, string) {
+	id, err := strconv.ParseInt(strings.TrimPrefix(strings.TrimSpace(requestIn), "#"), 10, 64)
+	if err != nil {
+		return "Укажите номер расписания, например: /unschedule 1", ""
+	}
+	schedule, deleted := b.dbAdapter.DeleteSchedule(entity.SessionPrefix(chatID, ownerID), id)
+	if !deleted {
+		return "Расписание не найдено.", ""
+	}
+
+	user, _ := b.dbAdapter.SelectUser(ownerID)
+	if !schedule.ActiveAt(time.Now(), user.Location()) {
+		return "Расписание удалено.", ""
+	}
+	return "Расписание удалено.", schedule.SessionID
+}
+
+func (b TaskUseCase) CheckSchedules(now time.Time) []entity.Schedule {
+	locations := map[string]*time.Location{}
+	schedules := []entity.Schedule{}
+	for _, schedule := range b.dbAdapter.SelectSchedules() {
+		_, ownerID, _ := entity.ParseSessionID(schedule.SessionID)
+		location, exists := locations[ownerID]
+		if !exists {
+			user, err := b.dbAdapter.SelectUser(ownerID)
+			if err != nil {
+				continue
+			}
+			location = user.Location()
+			locations[ownerID] = location
+		}
+		schedule.Active = schedule.ActiveAt(now, location)
+		schedules = append(schedules, schedule)
+	}
+	return schedules
+}
@@ -0,0 +1,30 @@
+package task
+
+import "testing"
+
+func TestGetWindowIn(t *testing.T) {
+	tests := []struct {
+		input   string
+		wantErr bool
+	}{
+		{input: "09:00-18:00"},
+		{input: "23:00-08:00"},
+		{input: "00:00-23:59"},
+		{input: "09:00-09:00", wantErr: true},
+		{input: "00:00-00:00", wantErr: true},
+		{input: "09:00", wantErr: true},
+		{input: "9am-18:00", wantErr: true},
+		{input: "09:00-24:00", wantErr: true},
+	}
+
+	for _, test := range tests {
+		from, to, err := TaskUseCase{}.getWindowIn(test.input)
+		if (err != nil) != test.wantErr {
+			t.Errorf("getWindowIn(%v) error = %v, wantErr %v", test.input, err, test.wantErr)
+			continue
+		}
+		if err == nil && from+"-"+to != test.input {
+			t.Errorf("getWindowIn(%v) = %v-%v", test.input, from, to)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package task
+
+import (
+	"crypto_pro/internal/domain/entity"
+	"fmt"
+	"strings"
+)
+
+func (b TaskUseCase) HideSymbol(userID, requestIn string) string {
+	symbols := b.splitList(requestIn)
+	if len(symbols) == 0 {
+		return "Укажите монету, например: /hide BTC"
+	}
+
+	if err := b.dbAdapter.AddUserSymbols(userID, entity.UserBlacklist, symbols); err != nil {
+		return "Не удалось сохранить черный список."
+	}
+	return fmt.Sprintf("Скрыто: %s", strings.Join(symbols, ", "))
+}
+
+func (b TaskUseCase) UnhideSymbol(userID, requestIn string) string {
+	symbols := b.splitList(requestIn)
+	if len(symbols) == 0 {
+		return "Укажите монету, например: /unhide BTC"
+	}
+
+	if err := b.dbAdapter.RemoveUserSymbols(userID, entity.UserBlacklist, symbols); err != nil {
+		return "Не удалось сохранить черный список."
+	}
+	return fmt.Sprintf("Снова показываю: %s", strings.Join(symbols, ", "))
+}
+
+func (b TaskUseCase) WatchSymbol(userID, requestIn string) string {
+	symbols := b.splitList(requestIn)
+	if len(symbols) == 0 {
+		return "Укажите монету, например: /watch BTC"
+	}
+
+	if err := b.dbAdapter.AddUserSymbols(userID, entity.UserWatchlist, symbols); err != nil {
+		return "Не удалось сохранить список избранных монет."
+	}
+	return fmt.Sprintf("Добавлено в избранные: %s", strings.Join(symbols, ", "))
+}
+
+func (b TaskUseCase) UnwatchSymbol(userID, requestIn string) string {
+	symbols := b.splitList(requestIn)
+	if len(symbols) == 0 {
+		return "Укажите монету, например: /unwatch BTC"
+	}
+
+	if err := b.dbAdapter.RemoveUserSymbols(userID, entity.UserWatchlist, symbols); err != nil {
+		return "Не удалось сохранить список избранных монет."
+	}
+	return fmt.Sprintf("Удалено из избранных: %s", strings.Join(symbols, ", "))
+}
+
+func (b TaskUseCase) SetWatchlistOnly(userID, requestIn string) string {
+	user, err := b.dbAdapter.SelectUser(userID)
+	if err != nil {
+		return "Не удалось сохранить режим избранных монет."
+	}
+
+	switch strings.ToLower(strings.TrimSpace(requestIn)) {
+	case "on":
+		user.WatchlistOnly = true
+	case "off":
+		user.WatchlistOnly = false
+	default:
+		return "Используйте /watchonly on или /watchonly off"
+	}
+
+	if err := b.dbAdapter.UpsertUserSettings(user); err != nil {
+		return "Не удалось сохранить режим избранных монет."
+	}
+	if user.WatchlistOnly {
+		return "Показываю только избранные монеты."
+	}
+	return "Показываю все монеты, кроме скрытых."
+}
+
+func (b TaskUseCase) GetSymbolLists(userID string) string {
+	user, err := b.dbAdapter.SelectUser(userID)
+	if err != nil {
+		return "Не удалось загрузить списки монет, попробуйте позже."
+	}
+
+	msgContent := fmt.Sprintf("🙈 Скрытые: %v \n", b.formatSymbols(user.Blacklist))
+	msgContent += fmt.Sprintf("👀 Избранные: %v \n", b.formatSymbols(user.Watchlist))
+	if user.WatchlistOnly {
+		msgContent += "Режим: только избранные"
+	} else {
+		msgContent += "Режим: все монеты"
+	}
+	return msgContent
+}
+
+func (b TaskUseCase) formatSymbols(symbols []string) string {
+	if len(symbols) == 0 {
+		return "нет"
+	}
+	return strings.Join(symbols, ", ")
+}
@@ -1,14 +1,22 @@
 package task
 
 import (
+	"cmp"
+	"context"
 	"crypto_pro/internal/adapters"
+	"crypto_pro/internal/chart"
 	"crypto_pro/internal/controller"
 	"crypto_pro/internal/domain/entity"
 	"crypto_pro/internal/domain/usecase"
+	"crypto_pro/internal/metrics"
+	"crypto_pro/internal/templates"
 	"crypto_pro/pkg/logger"
+	"errors"
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 )
 
 var _ usecase.TaskUseCase = (*TaskUseCase)(nil)
@@ -17,30 +25,67 @@ type TaskUseCase struct {
 	log              logger.Logger
 	serverController controller.Server
 	dbAdapter        adapters.DbAdapter
+	cards            *templates.Renderer
+	charts           *chart.Renderer
+	fetcher          *SpotFetcher
 }
 
 func New(log logger.Logger, serverController controller.Server, dbAdapter adapters.DbAdapter,
-) TaskUseCase {
-	return TaskUseCase{log: log, serverController: serverController, dbAdapter: dbAdapter}
+	cards *templates.Renderer, charts *chart.Renderer, fetcher *SpotFetcher) TaskUseCase {
+	return TaskUseCase{log: log, serverController: serverController, dbAdapter: dbAdapter, cards: cards,
+		charts: charts, fetcher: fetcher}
 }
 
-func (b TaskUseCase) HandleRequest(requestIn, id string) []entity.Transaction {
-	usdt, spreadMin, spreadMax := b.getDataIn(requestIn)
-	transactions := b.serverController.GetSpotHandler(usdt, spreadMin, spreadMax)
-	for i := range transactions {
-		transactions[i].SetID(id)
+func (b TaskUseCase) HandleRequest(id string) []entity.Transaction {
+	session := b.dbAdapter.SelectSession(id)
+	if session.ID == "" {
+		return nil
 	}
 
-	if len(transactions) == 0 {
+	spotTransactions, err := b.fetcher.GetSpot(session.USDT, session.SpreadMin, session.SpreadMax)
+	if err != nil {
 		return nil
 	}
+	return b.handleSpot(session, spotTransactions)
+}
+
+func (b TaskUseCase) HandleUpdate(id string, spotTransactions []entity.Transaction) []entity.Transaction {
+	session := b.dbAdapter.SelectSession(id)
+	if session.ID == "" {
+		return nil
+	}
+	return b.handleSpot(session, spotTransactions)
+}
 
-	err := b.dbAdapter.UpsertDWHTransactions(transactions)
+func (b TaskUseCase) SubscribeSession(ctx context.Context, id string) controller.SpotStream {
+	session := b.dbAdapter.SelectSession(id)
+	return b.serverController.SubscribeSpot(ctx, session.USDT, session.SpreadMin, session.SpreadMax)
+}
+
+func (b TaskUseCase) handleSpot(session entity.Session, spotTransactions []entity.Transaction) []entity.Transaction {
+	id := session.ID
+	user, err := b.dbAdapter.SelectUser(session.OwnerID())
+	if err != nil {
+		return nil
+	}
+	transactions := b.filterTransactions(spotTransactions, session, user)
+	for i := range transactions {
+		transactions[i].SetID(id)
+	}
+
+	err = b.dbAdapter.UpsertDWHTransactions(id, transactions)
 	if err != nil {
 		b.log.Error("Error when upserting transactions: %v", b.log.ErrorC(err))
 		return nil
 	}
 
+	if user.IsQuiet(time.Now()) {
+		if !session.QuietPending {
+			b.dbAdapter.UpdateSessionQuietPending(id, true)
+		}
+		return nil
+	}
+
 	newTransactions := b.dbAdapter.SelectNewTransactions(id)
 	if newTransactions == nil {
 		return []entity.Transaction{}
@@ -49,6 +94,30 @@ func (b TaskUseCase) HandleRequest(requestIn, id string) []entity.Transaction {
 	return newTransactions
 }
 
+func (b TaskUseCase) filterTransactions(transactions []entity.Transaction, session entity.Session,
+	user entity.User) []entity.Transaction {
+
+	filtered := []entity.Transaction{}
+	for _, transaction := range transactions {
+		transaction.NormalizeChain()
+		if !session.AllowsMarkets(transaction.MarketFrom, transaction.MarketTo) {
+			continue
+		}
+		if !session.AllowsChain(transaction.Chain) {
+			continue
+		}
+		if !user.AllowsSymbol(strings.ToUpper(transaction.Symbol)) {
+			continue
+		}
+		if reason := session.Guards.RejectReason(transaction); reason != "" {
+			metrics.RejectedDeals.WithLabelValues(reason).Inc()
+			continue
+		}
+		filtered = append(filtered, transaction)
+	}
+	return filtered
+}
+
 func (b TaskUseCase) GetAllTransactions(id string) []entity.Transaction {
 	transactions := b.dbAdapter.SelectTransactions(id)
 	if transactions == nil {
@@ -104,34 +173,169 @@ func (b TaskUseCase) GetInstruction() string {
 	- KUKOIN;
 	- MEXC;
 	- XT.
-Просто введи сумму необходимого количества USDT (целое), spread_min, spread_max (до одного знака после запятой) в % через пробел пример 100 0.3 0.5), чтобы я мог искать для тебя транзакции. Для остановки режима сканирования бирж отправь stop в чат, нажми на интересующую сделку и получишь всю необходимую информацию по ней или отправь all, чтобы получить все транзакции сразу.`
+Просто введи сумму необходимого количества USDT (целое), spread_min, spread_max (до одного знака после запятой) в % через пробел пример 100 0.3 0.5), чтобы я мог искать для тебя транзакции. Можно вести несколько сессий одновременно, добавив имя: 100 0.3 1 scalp и 5000 1 5 big; /sessions — список сессий, /edit big 3000 1 5 — изменить параметры, stop big — остановить одну сессию, stop — все. Команды настройки сессии принимают имя первым аргументом: /buy big BYBIT.
+В группах используйте команды: /run 100 0.3 1 [имя], /stop [имя], /all [имя], /help. У каждого участника свои сессии; администраторы видят все сессии через /sessions all и управляют ими по идентификатору владелец:имя, например /stop 12345:main. /topic <номер темы> направляет сделки в тему форума, /topic off — в общий чат. Для остановки режима сканирования бирж отправь stop в чат, нажми на интересующую сделку и получишь всю необходимую информацию по ней или отправь all, чтобы получить все транзакции сразу.
+Во время сессии можно ограничить биржи: /buy BYBIT MEXC — биржи покупки, /sell HTX — биржи продажи, /buy all — снять ограничение. Сети перевода: /chains TRC20 BEP20 — только эти сети, /chains all — любые, /nochains ERC20 — исключить сеть, /nochains none — ничего не исключать. Фильтры сделок: /guards fee=2 withdraw=on orders=2 depth=50 — комиссия вывода не более 2% от объема, объем не больше лимита вывода, не меньше 2 ордеров с каждой стороны и глубина от 50 USDT, /guards off — отключить. Оповещения об изменении спреда уже отправленных сделок: /alerts abs=0.5 rel=50 — при изменении на 0.5 п.п. или на 50% от прежнего значения, /alerts off — отключить. Уведомления о закрытии отправленных сделок: /closed on|off.
+Время: /tz Europe/Moscow — часовой пояс, /quiet 23:00-08:00 — тихие часы (поиск продолжается, уведомления придут сводкой после), /quiet off — отключить. Расписание: /schedule 100 0.3 1 mon,tue,wed,thu,fri 09:00-18:00 — запускать и останавливать сессию автоматически, /schedule — список, /unschedule 1 — удалить. Текущие параметры сессии покажет /status.
+Монеты: /hide BTC — скрыть монету (или кнопка на карточке сделки), /unhide BTC — вернуть, /watch BTC и /unwatch BTC — список избранных монет, /watchonly on|off — показывать только избранные, /lists — текущие списки. Избранное: кнопка «⭐» на карточке сделки закрепляет маршрут (монета, сеть, биржи) и присылает уведомление, когда его спред пересекает порог, даже вне диапазона сессии; /favorites — список с текущим спредом, /favorites 3 1.5 — порог для #3, /unfavorite 3 — удалить.
+Дайджест: /digest daily 09:00 или /digest weekly mon 09:00 — сводка по лучшим спредам, частым маршрутам, биржам и средней жизни сделки в ваше местное время, /digest now — сводка за сутки сейчас, /digest off — отключить.
+Выгрузка: /export [имя] csv|xlsx 24h|7d open|closed|all symbol=BTC from=BYBIT to=MEXC chain=TRC20 spread=1 — файл со сделками за период (по умолчанию CSV за 24 часа). Кнопка «🔄 Обновить» на карточке сделки заново запросит пару и обновит карточку, «📈 График» пришлет график спреда и стоимости покупки/продажи за последние часы.`
 }
 
 func (b TaskUseCase) GetInfoAboutTransactions(id string, marketFrom, marketTo, symbol string,
-) string {
+) (string, string) {
 
 	transaction := b.dbAdapter.SelectTransactionsBySymbol(id, symbol, marketFrom, marketTo)
 	if transaction.ID == "" {
-		return "ой, 😀 сделка уже не отслеживается, так как она перестала быть интересной для тебя"
-	}
-	msgContent := fmt.Sprintf("%v \n", transaction.Symbol)
-	msgContent += fmt.Sprintf("📕|%v| \n", transaction.MarketFrom)
-	msgContent += fmt.Sprintf("*Сеть:* %v \n", transaction.Chain)
-	msgContent += fmt.Sprintf("*Объем б/к:* %.4f %v \n", transaction.AmountCoin, transaction.Symbol)
-	msgContent += fmt.Sprintf("*Комиссия:* %v %v \n", transaction.WithDrawFee, transaction.Symbol)
-	msgContent += fmt.Sprintf("*Кол-во ордеров:* %v \n", transaction.AmountAskOrder)
-	msgContent += fmt.Sprintf("*Стоимость покупки:* %.0f USDT \n", transaction.AskCost)
-	msgContent += fmt.Sprintf("*Ордера (Цена/Кол-во):* %v \n", transaction.AskOrder)
-	msgContent += fmt.Sprintf("📗|%v| \n", transaction.MarketTo)
-	msgContent += fmt.Sprintf("*Кол-во ордеров:* %v \n", transaction.AmountBidOrder)
-	msgContent += fmt.Sprintf("*Стоимость продажи:* %.2f USDT \n", transaction.BidCost)
-	msgContent += fmt.Sprintf("*Ордера (Цена/Кол-во):* %v \n", transaction.BidOrder)
-	msgContent += "--- \n"
-	msgContent += fmt.Sprintf("💰 *Спред:* %.2f %%", transaction.Spread)
-	return msgContent
+		return b.cards.Escape(
+			"ой, 😀 сделка уже не отслеживается, так как она перестала быть интересной для тебя"), ""
+	}
+	msgContent, err := b.cards.Card(transaction)
+	if err != nil {
+		b.log.Error("Error when rendering card", b.log.ErrorC(err))
+		return b.cards.Escape("Не удалось сформировать карточку сделки."), ""
+	}
+	return msgContent, transaction.Chain
+}
+
+func (b TaskUseCase) RefreshTransaction(id string, marketFrom, marketTo, symbol string) (string, string) {
+	session := b.dbAdapter.SelectSession(id)
+	if session.ID == "" {
+		return b.cards.Escape("Нет активной сессии."), ""
+	}
+
+	spotTransactions, err := b.serverController.GetSpotPair(session.USDT, session.SpreadMin,
+		session.SpreadMax, symbol, marketFrom, marketTo)
+	fetchedAt := time.Now()
+	if errors.Is(err, controller.ErrSpotCircuitOpen) {
+		return b.cards.Escape("Сервис котировок временно недоступен, попробуйте через минуту."), ""
+	}
+	if err != nil {
+		return b.cards.Escape("Не удалось получить данные по сделке, попробуйте позже."), ""
+	}
+
+	user, err := b.dbAdapter.SelectUser(session.OwnerID())
+	if err != nil {
+		return b.cards.Escape("Не удалось получить данные по сделке, попробуйте позже."), ""
+	}
+
+	transactions := b.filterTransactions(spotTransactions, session, user)
+	if len(transactions) == 0 {
+		return b.cards.Escape(fmt.Sprintf("⛔ %v %v → %v больше не проходит условия сессии.", symbol,
+			marketFrom, marketTo)), ""
+	}
+
+	transaction := slices.MaxFunc(transactions, func(a, b entity.Transaction) int {
+		return cmp.Compare(a.Spread, b.Spread)
+	})
+	transaction.UpdatedAt = fetchedAt
+
+	msgContent, err := b.cards.Card(transaction)
+	if err != nil {
+		b.log.Error("Error when rendering card", b.log.ErrorC(err))
+		return b.cards.Escape("Не удалось сформировать карточку сделки."), ""
+	}
+	return msgContent, transaction.Chain
+}
+
+func (b TaskUseCase) GetCardParseMode() string {
+	return b.cards.ParseMode()
 }
 
 func (b TaskUseCase) CreateSession(id, requestIn string) {
 	usdt, spreadMin, spreadMax := b.getDataIn(requestIn)
 	b.dbAdapter.CreateSession(id, usdt, spreadMin, spreadMax)
 }
+
+func (b TaskUseCase) EditSession(id, requestIn string) string {
+	session := b.dbAdapter.SelectSession(id)
+	if session.ID == "" {
+		return "Нет активной сессии."
+	}
+
+	b.CreateSession(id, requestIn)
+	return b.GetStatus(id)
+}
+
+func (b TaskUseCase) SetBuyMarkets(id, requestIn string) string {
+	session := b.dbAdapter.SelectSession(id)
+	if session.ID == "" {
+		return "Нет активной сессии."
+	}
+
+	markets, err := b.getMarketsIn(requestIn)
+	if err != nil {
+		return err.Error()
+	}
+
+	if err := b.dbAdapter.UpdateSessionMarkets(id, markets, session.SellMarkets); err != nil {
+		return "Не удалось сохранить биржи покупки."
+	}
+	return "Биржи покупки: " + b.formatMarkets(markets)
+}
+
+func (b TaskUseCase) SetSellMarkets(id, requestIn string) string {
+	session := b.dbAdapter.SelectSession(id)
+	if session.ID == "" {
+		return "Нет активной сессии."
+	}
+
+	markets, err := b.getMarketsIn(requestIn)
+	if err != nil {
+		return err.Error()
+	}
+
+	if err := b.dbAdapter.UpdateSessionMarkets(id, session.BuyMarkets, markets); err != nil {
+		return "Не удалось сохранить биржи продажи."
+	}
+	return "Биржи продажи: " + b.formatMarkets(markets)
+}
+
+func (b TaskUseCase) splitList(input string) []string {
+	return strings.FieldsFunc(strings.ToUpper(input), func(r rune) bool {
+		return r == ' ' || r == ','
+	})
+}
+
+func (b TaskUseCase) getMarketsIn(input string) ([]string, error) {
+	markets := []string{}
+	for _, field := range b.splitList(input) {
+		if field == "ALL" {
+			return []string{}, nil
+		}
+		if !slices.Contains(entity.Markets, field) {
+			return nil, fmt.Errorf("Неизвестная биржа %s. Доступны: %s", field,
+				strings.Join(entity.Markets, ", "))
+		}
+		if !slices.Contains(markets, field) {
+			markets = append(markets, field)
+		}
+	}
+	return markets, nil
+}
+
+func (b TaskUseCase) formatMarkets(markets []string) string {
+	if len(markets) == 0 {
+		return "все"
+	}
+	return strings.Join(markets, ", ")
+}
+
+func (b TaskUseCase) GetStatus(id string) string {
+	session := b.dbAdapter.SelectSession(id)
+	if session.ID == "" {
+		return "Нет активной сессии."
+	}
+
+	msgContent := fmt.Sprintf("📊 Сессия %v активна \n", session.Name())
+	msgContent += fmt.Sprintf("USDT: %v \n", session.USDT)
+	msgContent += fmt.Sprintf("Спред: %v - %v %% \n", session.SpreadMin, session.SpreadMax)
+	msgContent += fmt.Sprintf("📕 Биржи покупки: %v \n", b.formatMarkets(session.BuyMarkets))
+	msgContent += fmt.Sprintf("📗 Биржи продажи: %v \n", b.formatMarkets(session.SellMarkets))
+	msgContent += fmt.Sprintf("🔗 Только сети: %v \n", b.formatMarkets(session.IncludeChains))
+	msgContent += fmt.Sprintf("⛔ Исключенные сети: %v \n", b.formatSymbols(session.ExcludeChains))
+	msgContent += b.formatGuards(session.Guards) + " \n"
+	msgContent += b.formatAlerts(session.Alerts) + " \n"
+	msgContent += b.formatNotifyClosed(session.NotifyClosed)
+	return msgContent
+}
@@ -1,17 +1,69 @@
 package usecase
 
 import (
+	"context"
+	"crypto_pro/internal/controller"
 	"crypto_pro/internal/domain/entity"
+	"io"
+	"time"
 )
 
 type TaskUseCase interface {
-	HandleRequest(requestIn string, id string) []entity.Transaction
+	HandleRequest(id string) []entity.Transaction
+	HandleUpdate(id string, spotTransactions []entity.Transaction) []entity.Transaction
+	SubscribeSession(ctx context.Context, id string) controller.SpotStream
+	GetSpot(usdt, spreadMin, spreadMax float64) ([]entity.Transaction, error)
+	GetSessions(prefix string) []entity.Session
+	GetSession(id string) (entity.Session, error)
+	GetCard(id, marketFrom, marketTo, symbol string) (string, error)
+	SetSessionFilters(id string, filters entity.SessionFilters) (entity.SessionFilters, error)
 	DeleteSession(id string)
 	TrancateRawTransactions()
 	TrancateDwhTransactions()
-	GetInfoAboutTransactions(id string, marketFrom, marketTo, symbol string) string
+	GetInfoAboutTransactions(id string, marketFrom, marketTo, symbol string) (string, string)
+	GetCardParseMode() string
+	RefreshTransaction(id string, marketFrom, marketTo, symbol string) (string, string)
+	GetExportFilter(requestIn string) (entity.ExportFilter, error)
+	ExportTransactions(id string, filter entity.ExportFilter, w io.Writer) error
+	GetSpreadChart(id, marketFrom, marketTo, symbol, chain string) ([]byte, string)
 	GetTransactions(id string) []entity.Transaction
 	GetInstruction() string
 	GetAllTransactions(id string) []entity.Transaction
 	CreateSession(id, requestIn string)
+	EditSession(id, requestIn string) string
+	SetBuyMarkets(id, requestIn string) string
+	SetSellMarkets(id, requestIn string) string
+	GetStatus(id string) string
+	SetIncludeChains(id, requestIn string) string
+	SetExcludeChains(id, requestIn string) string
+	SetGuards(id, requestIn string) string
+	SetAlerts(id, requestIn string) string
+	GetSpreadAlerts(id string) []string
+	SetNotifyClosed(id, requestIn string) string
+	GetClosedTransactions(id string) ([]entity.Transaction, bool)
+	GetClosedText(id string, transaction entity.Transaction) string
+	HideSymbol(userID, requestIn string) string
+	UnhideSymbol(userID, requestIn string) string
+	WatchSymbol(userID, requestIn string) string
+	UnwatchSymbol(userID, requestIn string) string
+	SetWatchlistOnly(userID, requestIn string) string
+	GetSymbolLists(userID string) string
+	SetTimeZone(userID, requestIn string) string
+	SetQuietHours(userID, requestIn string) string
+	GetQuietSummary(id string) (string, []entity.Transaction)
+	AddSchedule(chatID, ownerID, requestIn string) string
+	GetSchedules(chatID, ownerID string) string
+	DeleteSchedule(chatID, ownerID, requestIn string) (string, string)
+	CheckSchedules(now time.Time) []entity.Schedule
+	AddFavorite(userID, id, marketFrom, marketTo, symbol string) string
+	GetFavorites(userID string) string
+	SetFavoriteThreshold(userID, requestIn string) string
+	DeleteFavorite(userID, requestIn string) string
+	CheckFavorites() []entity.FavoriteAlert
+	SetDigest(chatID, userID, requestIn string) string
+	GetDigest(userID string, digest entity.Digest) string
+	CheckDigests(now time.Time) []entity.DigestReport
+	MarkDigestSent(userID string, sentAt time.Time) error
+	SetChatThread(chatID string, threadID int) string
+	GetChatThread(chatID string) int
 }
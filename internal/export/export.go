@@ -0,0 +1,146 @@
+package export
+
+import (
+	"crypto_pro/internal/domain/entity"
+	"encoding/csv"
+	"errors"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+const (
+	FormatCSV   = "csv"
+	FormatXLSX  = "xlsx"
+	MaxXLSXRows = 100000
+)
+
+var ErrTooManyRows = errors.New("too many rows for xlsx export")
+
+var header = []string{"status", "symbol", "chain", "market_from", "market_to", "spread", "peak_spread",
+	"amount_coin", "withdraw_fee", "ask_orders", "ask_cost", "ask_avg_price", "bid_orders", "bid_cost",
+	"bid_avg_price", "created_at", "updated_at", "closed_at"}
+
+type Writer interface {
+	Write(transaction entity.Transaction) error
+	Close() error
+}
+
+func NewWriter(format string, w io.Writer) (Writer, error) {
+	if format == FormatXLSX {
+		return newXLSXWriter(w)
+	}
+	return newCSVWriter(w)
+}
+
+func row(transaction entity.Transaction) []interface{} {
+	status := "open"
+	if !transaction.ClosedAt.IsZero() {
+		status = "closed"
+	}
+
+	return []interface{}{status, transaction.Symbol, transaction.Chain, transaction.MarketFrom,
+		transaction.MarketTo, transaction.Spread, transaction.PeakSpread, transaction.AmountCoin,
+		transaction.WithDrawFee, transaction.AmountAskOrder, transaction.AskCost,
+		avgPrice(transaction.AskCost, transaction.AmountCoin), transaction.AmountBidOrder, transaction.BidCost,
+		avgPrice(transaction.BidCost, transaction.AmountCoin), formatTime(transaction.CreatedAt),
+		formatTime(transaction.UpdatedAt), formatTime(transaction.ClosedAt)}
+}
+
+func avgPrice(cost, amount float64) float64 {
+	if amount == 0 {
+		return 0
+	}
+	return cost / amount
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+type csvWriter struct {
+	writer *csv.Writer
+}
+
+func newCSVWriter(w io.Writer) (*csvWriter, error) {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(header); err != nil {
+		return nil, err
+	}
+	return &csvWriter{writer: writer}, nil
+}
+
+func (c *csvWriter) Write(transaction entity.Transaction) error {
+	values := row(transaction)
+	record := make([]string, 0, len(values))
+	for _, value := range values {
+		switch value := value.(type) {
+		case float64:
+			record = append(record, strconv.FormatFloat(value, 'f', -1, 64))
+		case string:
+			record = append(record, value)
+		}
+	}
+
+	if err := c.writer.Write(record); err != nil {
+		return err
+	}
+	c.writer.Flush()
+	return c.writer.Error()
+}
+
+func (c *csvWriter) Close() error {
+	c.writer.Flush()
+	return c.writer.Error()
+}
+
+// xlsxWriter spools rows to excelize's temp file and writes the workbook only on Close,
+// so nothing reaches the output until the export finishes. Rows are capped by MaxXLSXRows.
+type xlsxWriter struct {
+	file   *excelize.File
+	stream *excelize.StreamWriter
+	output io.Writer
+	rowID  int
+}
+
+func newXLSXWriter(w io.Writer) (*xlsxWriter, error) {
+	file := excelize.NewFile()
+	stream, err := file.NewStreamWriter("Sheet1")
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]interface{}, 0, len(header))
+	for _, name := range header {
+		values = append(values, name)
+	}
+	if err := stream.SetRow("A1", values); err != nil {
+		return nil, err
+	}
+	return &xlsxWriter{file: file, stream: stream, output: w, rowID: 1}, nil
+}
+
+func (x *xlsxWriter) Write(transaction entity.Transaction) error {
+	if x.rowID > MaxXLSXRows {
+		return ErrTooManyRows
+	}
+	x.rowID++
+	cell, err := excelize.CoordinatesToCellName(1, x.rowID)
+	if err != nil {
+		return err
+	}
+	return x.stream.SetRow(cell, row(transaction))
+}
+
+func (x *xlsxWriter) Close() error {
+	defer x.file.Close()
+	if err := x.stream.Flush(); err != nil {
+		return err
+	}
+	return x.file.Write(x.output)
+}
@@ -0,0 +1,41 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var RejectedDeals = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "crypto_pro_rejected_deals_total",
+	Help: "Number of deals rejected by session guards.",
+}, []string{"reason"})
+
+var SpotEndpointUp = promauto.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "crypto_pro_spot_endpoint_up",
+	Help: "Whether the spot endpoint passed the last health check or request.",
+}, []string{"endpoint"})
+
+var SpotRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "crypto_pro_spot_requests_total",
+	Help: "Number of spot requests by endpoint and result.",
+}, []string{"endpoint", "result"})
+
+var SpotFailovers = promauto.NewCounter(prometheus.CounterOpts{
+	Name: "crypto_pro_spot_failovers_total",
+	Help: "Number of times a spot endpoint was taken out of rotation.",
+})
+
+var SpotFetches = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "crypto_pro_spot_fetches_total",
+	Help: "Number of session spot requests by whether they triggered a fetch or shared one.",
+}, []string{"result"})
+
+var SpotStreams = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "crypto_pro_spot_streams_total",
+	Help: "Number of spot stream connection events by result.",
+}, []string{"result"})
+
+var InvalidDeals = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "crypto_pro_invalid_deals_total",
+	Help: "Number of spot deals dropped by validation by rule and market.",
+}, []string{"rule", "market"})
@@ -0,0 +1,155 @@
+package templates
+
+import (
+	"bytes"
+	"crypto_pro/internal/domain/entity"
+	"crypto_pro/pkg/logger"
+	"embed"
+	"fmt"
+	"html"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+const (
+	ParseModeMarkdownV2 = "MarkdownV2"
+	ParseModeHTML       = "HTML"
+)
+
+//go:embed default/*.tmpl
+var defaults embed.FS
+
+var markdownV2Replacer = strings.NewReplacer(
+	`\`, `\\`, "_", `\_`, "*", `\*`, "[", `\[`, "]", `\]`, "(", `\(`, ")", `\)`, "~", `\~`, "`", "\\`",
+	">", `\>`, "#", `\#`, "+", `\+`, "-", `\-`, "=", `\=`, "|", `\|`, "{", `\{`, "}", `\}`, ".", `\.`,
+	"!", `\!`,
+)
+
+type Renderer struct {
+	parseMode string
+	card      *template.Template
+	alert     *template.Template
+	closed    *template.Template
+	favorite  *template.Template
+}
+
+type closedCard struct {
+	Session string
+	entity.Transaction
+}
+
+func New(cfg viper.Viper, log logger.Logger) *Renderer {
+	renderer := &Renderer{parseMode: ParseModeMarkdownV2}
+	switch parseMode := strings.TrimSpace(cfg.GetString("templates.parse_mode")); {
+	case strings.EqualFold(parseMode, ParseModeHTML):
+		renderer.parseMode = ParseModeHTML
+	case parseMode != "" && !strings.EqualFold(parseMode, ParseModeMarkdownV2):
+		log.Error("Unknown parse mode, use MarkdownV2", log.StringC("ParseMode", parseMode))
+	}
+
+	dir := cfg.GetString("templates.dir")
+	renderer.card = renderer.load("card", dir, log)
+	renderer.alert = renderer.load("alert", dir, log)
+	renderer.closed = renderer.load("closed", dir, log)
+	renderer.favorite = renderer.load("favorite", dir, log)
+	return renderer
+}
+
+func (r *Renderer) load(kind, dir string, log logger.Logger) *template.Template {
+	name := kind + "." + strings.ToLower(r.parseMode) + ".tmpl"
+	tmpl, err := template.New(name).Funcs(r.funcs()).ParseFS(defaults, "default/"+name)
+	if err != nil {
+		panic(fmt.Sprintf("error parse default template %v: %v", name, err))
+	}
+
+	if dir == "" {
+		return tmpl
+	}
+	override := filepath.Join(dir, name)
+	if _, err := os.Stat(override); err != nil {
+		return tmpl
+	}
+	tmpl, err = template.New(name).Funcs(r.funcs()).ParseFiles(override)
+	if err != nil {
+		panic(fmt.Sprintf("error parse template %v: %v", override, err))
+	}
+	log.Info("Use custom "+kind+" template", log.StringC("Path", override))
+	return tmpl
+}
+
+func (r *Renderer) ParseMode() string {
+	return r.parseMode
+}
+
+func (r *Renderer) Escape(text string) string {
+	if r.parseMode == ParseModeHTML {
+		return html.EscapeString(text)
+	}
+	return markdownV2Replacer.Replace(text)
+}
+
+func (r *Renderer) Card(transaction entity.Transaction) (string, error) {
+	return r.execute(r.card, transaction)
+}
+
+func (r *Renderer) Alert(change entity.SpreadChange) (string, error) {
+	return r.execute(r.alert, change)
+}
+
+func (r *Renderer) Closed(session string, transaction entity.Transaction) (string, error) {
+	return r.execute(r.closed, closedCard{Session: session, Transaction: transaction})
+}
+
+func (r *Renderer) Favorite(favorite entity.Favorite) (string, error) {
+	return r.execute(r.favorite, favorite)
+}
+
+func (r *Renderer) execute(tmpl *template.Template, data any) (string, error) {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(buf.String()), nil
+}
+
+func (r *Renderer) funcs() template.FuncMap {
+	return template.FuncMap{
+		"esc": r.Escape,
+		"num": func(value float64, precision int) string {
+			return r.Escape(strconv.FormatFloat(value, 'f', precision, 64))
+		},
+		"ago": func(updatedAt time.Time) string {
+			if updatedAt.IsZero() {
+				return ""
+			}
+			return r.Escape(fmt.Sprintf("🕒 обновлено %v с назад", int(time.Since(updatedAt).Seconds())))
+		},
+		"lifetime": func(lifetime time.Duration) string {
+			return r.Escape(lifetime.Round(time.Second).String())
+		},
+		"best": func(orders []entity.Order) string {
+			if len(orders) == 0 {
+				return r.Escape("-")
+			}
+			return r.Escape(strconv.FormatFloat(orders[0].Price, 'f', -1, 64))
+		},
+		"orders": func(orders []entity.Order) string {
+			if len(orders) == 0 {
+				return r.Escape("нет")
+			}
+
+			lines := make([]string, 0, len(orders))
+			for _, order := range orders {
+				lines = append(lines, r.Escape(fmt.Sprintf("  %v / %v",
+					strconv.FormatFloat(order.Price, 'f', -1, 64), strconv.FormatFloat(order.Qty, 'f', -1, 64))))
+			}
+			return strings.Join(lines, "\n")
+		},
+	}
+}
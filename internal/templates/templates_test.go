@@ -0,0 +1,122 @@
+package templates
+
+import (
+	"crypto_pro/internal/domain/entity"
+	"crypto_pro/pkg/logger"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+var update = flag.Bool("update", false, "rewrite golden files")
+
+var createdAt = time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+var goldenTransactions = map[string]entity.Transaction{
+	"special": {
+		Symbol: "A_B*C", Chain: "BEP-20_(BSC)[v2]", MarketFrom: "Gate.io", MarketTo: "Huobi-Global",
+		Spread: 1.235, WithDrawFee: 0.5, AmountCoin: 12.5, AmountAskOrder: 2, AskCost: 100.4,
+		AskOrder: []entity.Order{{Price: 4.01, Qty: 10}, {Price: 4.02, Qty: 2.5}}, AmountBidOrder: 1,
+		BidCost: 101.64, BidOrder: []entity.Order{{Price: 8.1312, Qty: 12.5}}, PeakSpread: 2.5,
+		CreatedAt: createdAt, ClosedAt: createdAt.Add(95 * time.Minute),
+	},
+	"empty": {
+		Symbol: "BTC", Chain: "BTC", MarketFrom: "Binance", MarketTo: "Bybit", AskOrder: []entity.Order{},
+		BidOrder: []entity.Order{},
+	},
+	"numbers": {
+		Symbol: "SHIB", Chain: "ERC20", MarketFrom: "OKX", MarketTo: "MEXC", Spread: 123456.789,
+		WithDrawFee: 0.00000001, AmountCoin: 1234567890.12345, AmountAskOrder: 150, AskCost: 9876543210.5,
+		AskOrder: []entity.Order{{Price: 0.00000812, Qty: 1e12}}, AmountBidOrder: 3, BidCost: 0.004,
+		BidOrder: []entity.Order{{Price: 1e-8, Qty: 0.5}}, PeakSpread: 0.001,
+		CreatedAt: createdAt, ClosedAt: createdAt.Add(1500 * time.Millisecond),
+	},
+}
+
+func newRenderer(t *testing.T, parseMode string) *Renderer {
+	t.Helper()
+
+	cfg := viper.New()
+	cfg.Set("templates.parse_mode", parseMode)
+	return New(*cfg, logger.New(false))
+}
+
+func TestParseMode(t *testing.T) {
+	tests := []struct {
+		config string
+		want   string
+	}{
+		{config: "", want: ParseModeMarkdownV2},
+		{config: "MarkdownV2", want: ParseModeMarkdownV2},
+		{config: "markdownv2", want: ParseModeMarkdownV2},
+		{config: "HTML", want: ParseModeHTML},
+		{config: "html", want: ParseModeHTML},
+		{config: " Html ", want: ParseModeHTML},
+		{config: "Markdown", want: ParseModeMarkdownV2},
+	}
+
+	for _, test := range tests {
+		if got := newRenderer(t, test.config).ParseMode(); got != test.want {
+			t.Errorf("parse mode %q = %v, want %v", test.config, got, test.want)
+		}
+	}
+}
+
+func TestGolden(t *testing.T) {
+	for _, parseMode := range []string{ParseModeMarkdownV2, ParseModeHTML} {
+		renderer := newRenderer(t, parseMode)
+		for name, transaction := range goldenTransactions {
+			previous := transaction
+			previous.Spread, previous.AskCost, previous.BidCost = transaction.Spread*2, transaction.AskCost/2, 0
+			previous.AskOrder, previous.BidOrder = transaction.BidOrder, transaction.AskOrder
+
+			favorite := entity.Favorite{Symbol: transaction.Symbol, Chain: transaction.Chain,
+				MarketFrom: transaction.MarketFrom, MarketTo: transaction.MarketTo, Threshold: 0.75,
+				Spread: transaction.Spread, IsAbove: transaction.Spread >= 0.75}
+
+			renders := map[string]func() (string, error){
+				"card": func() (string, error) { return renderer.Card(transaction) },
+				"alert": func() (string, error) {
+					return renderer.Alert(entity.SpreadChange{Previous: previous, Current: transaction})
+				},
+				"closed":   func() (string, error) { return renderer.Closed("main_1", transaction) },
+				"favorite": func() (string, error) { return renderer.Favorite(favorite) },
+			}
+			for kind, render := range renders {
+				file := kind + "_" + name + "." + strings.ToLower(parseMode) + ".golden"
+				t.Run(file, func(t *testing.T) {
+					got, err := render()
+					if err != nil {
+						t.Fatal(err)
+					}
+					assertGolden(t, file, got)
+				})
+			}
+		}
+	}
+}
+
+func assertGolden(t *testing.T, file, got string) {
+	t.Helper()
+
+	path := filepath.Join("testdata", file)
+	if *update {
+		if err := os.WriteFile(path, []byte(got+"\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != strings.TrimSuffix(string(want), "\n") {
+		t.Errorf("%v mismatch, run go test -update\ngot:\n%v\nwant:\n%s", file, got, want)
+	}
+}
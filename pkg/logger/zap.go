@@ -2,6 +2,7 @@ package logger
 
 import (
 	"os"
+	"path/filepath"
 
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
@@ -19,6 +20,14 @@ func New(parameter bool) *Log {
 	}
 }
 
+// NewFile returns a logger writing only to the given file, for records that
+// should be kept apart from the main log.
+func NewFile(filename string) *Log {
+	return &Log{
+		driver: &logD{zap.New(zapcore.NewCore(newJSONEncoder(), newFileWriter(filename), zapcore.DebugLevel))},
+	}
+}
+
 func (l *Log) Info(msg string, fields ...zap.Field) {
 	l.driver.Info(msg, fields...)
 }
@@ -90,39 +99,8 @@ func (l *logD) setHook(parameter bool) {
 }
 
 func (l *logD) createFileCore() zapcore.Core {
-	err := os.MkdirAll("logs", 0777)
-	if err != nil {
-		panic(err)
-	}
-
-	jsonEncoder := zapcore.NewJSONEncoder(
-		zapcore.EncoderConfig{
-			MessageKey:     "message",
-			LevelKey:       "level",
-			TimeKey:        "time",
-			NameKey:        "logger",
-			CallerKey:      "caller",
-			FunctionKey:    "function",
-			StacktraceKey:  "stacktrace",
-			LineEnding:     zapcore.DefaultLineEnding,
-			EncodeLevel:    zapcore.LowercaseLevelEncoder,
-			EncodeTime:     zapcore.ISO8601TimeEncoder,
-			EncodeDuration: zapcore.SecondsDurationEncoder,
-			EncodeCaller:   zapcore.ShortCallerEncoder,
-			EncodeName:     zapcore.FullNameEncoder,
-		},
-	)
-
-	fileWriter := zapcore.AddSync(
-		&lumberjack.Logger{
-			Filename:   "logs/all.log",
-			MaxSize:    50,
-			MaxBackups: 1,
-			Compress:   true,
-			LocalTime:  true,
-		},
-	)
-
+	jsonEncoder := newJSONEncoder()
+	fileWriter := newFileWriter("logs/all.log")
 	stdoutWriter := zapcore.AddSync(os.Stdout)
 
 	return zapcore.NewTee(
@@ -132,7 +110,13 @@ func (l *logD) createFileCore() zapcore.Core {
 }
 
 func (l *logD) createStdoutCore() zapcore.Core {
-	jsonEncoder := zapcore.NewJSONEncoder(
+	stdoutWriter := zapcore.AddSync(os.Stdout)
+
+	return zapcore.NewCore(newJSONEncoder(), stdoutWriter, zapcore.DebugLevel)
+}
+
+func newJSONEncoder() zapcore.Encoder {
+	return zapcore.NewJSONEncoder(
 		zapcore.EncoderConfig{
 			MessageKey:     "message",
 			LevelKey:       "level",
@@ -149,8 +133,21 @@ func (l *logD) createStdoutCore() zapcore.Core {
 			EncodeName:     zapcore.FullNameEncoder,
 		},
 	)
+}
 
-	stdoutWriter := zapcore.AddSync(os.Stdout)
+func newFileWriter(filename string) zapcore.WriteSyncer {
+	err := os.MkdirAll(filepath.Dir(filename), 0777)
+	if err != nil {
+		panic(err)
+	}
 
-	return zapcore.NewCore(jsonEncoder, stdoutWriter, zapcore.DebugLevel)
+	return zapcore.AddSync(
+		&lumberjack.Logger{
+			Filename:   filename,
+			MaxSize:    50,
+			MaxBackups: 1,
+			Compress:   true,
+			LocalTime:  true,
+		},
+	)
 }